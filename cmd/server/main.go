@@ -1,25 +1,61 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	_ "song-library/docs" // docs is generated by Swag CLI, you have to import it.
+	"song-library/internal/clients/musicinfo"
 	"song-library/internal/config"
+	addAlbum "song-library/internal/http-server/handlers/albums/add"
+	deleteAlbum "song-library/internal/http-server/handlers/albums/delete"
+	getAlbums "song-library/internal/http-server/handlers/albums/get"
+	updateAlbum "song-library/internal/http-server/handlers/albums/update"
+	addArtist "song-library/internal/http-server/handlers/artists/add"
+	deleteArtist "song-library/internal/http-server/handlers/artists/delete"
+	getArtists "song-library/internal/http-server/handlers/artists/get"
+	mergeArtists "song-library/internal/http-server/handlers/artists/merge"
+	updateArtist "song-library/internal/http-server/handlers/artists/update"
+	"song-library/internal/http-server/handlers/health/liveness"
+	"song-library/internal/http-server/handlers/health/readiness"
 	"song-library/internal/http-server/handlers/info"
 	"song-library/internal/http-server/handlers/songs/add"
 	delete2 "song-library/internal/http-server/handlers/songs/delete"
 	getSongs "song-library/internal/http-server/handlers/songs/get"
+	lyricsDiff "song-library/internal/http-server/handlers/songs/lyrics/diff"
 	getLyrics "song-library/internal/http-server/handlers/songs/lyrics/get"
+	"song-library/internal/http-server/handlers/songs/patch"
+	"song-library/internal/http-server/handlers/songs/revisions"
+	"song-library/internal/http-server/handlers/songs/search"
+	songTags "song-library/internal/http-server/handlers/songs/tags"
 	"song-library/internal/http-server/handlers/songs/update"
+	"song-library/internal/http-server/handlers/suggest"
+	"song-library/internal/http-server/handlers/tags"
+	"song-library/internal/http-server/handlers/trash"
+	mwAuthor "song-library/internal/http-server/middleware/author"
 	mwLogger "song-library/internal/http-server/middleware/logger"
+	mwMetrics "song-library/internal/http-server/middleware/metrics"
 	"song-library/internal/lib/logger/sl"
 	"song-library/internal/lib/logger/slogpretty"
+	"song-library/internal/purger"
+	"song-library/internal/storage"
+	"song-library/internal/storage/instrumented"
+	"song-library/internal/storage/memory"
 	"song-library/internal/storage/postgres"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	_ "github.com/swaggo/files"
 	"github.com/swaggo/http-swagger/v2"
 )
@@ -33,6 +69,7 @@ const (
 // @title Song library API
 // @version 0.0.1
 // @description API server for song library
+// @description Every change of a song is recorded as a revision; the optional X-User header names its author.
 
 // @contact.name Aliev Farid
 // @contact.url https://github.com/farid21ola
@@ -47,30 +84,119 @@ func main() {
 	log.Info("starting song-library", slog.String("env", cfg.Env))
 	log.Debug("debug messages are enabled")
 
-	storage, err := postgres.New(cfg.ConnectionString)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(context.Background(), log, cfg, os.Args[2:]); err != nil {
+			log.Error("migration failed", sl.Err(err))
+			os.Exit(1)
+		}
+		return
+	}
+
+	if cfg.Storage == config.StoragePostgres && cfg.MigrateOnStart {
+		if err := migrateOnStart(context.Background(), log, cfg); err != nil {
+			log.Error("failed to migrate database", sl.Err(err))
+			os.Exit(1)
+		}
+	}
+
+	store, err := setupStorage(cfg)
 	if err != nil {
 		log.Error("failed to init storage", sl.Err(err))
 		os.Exit(1)
 	}
 
-	log.Info("storage connected")
+	log.Info("storage connected", slog.String("storage", cfg.Storage))
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	if pool, ok := store.(instrumented.PoolStater); ok {
+		reg.MustRegister(instrumented.NewPoolCollector(pool))
+	}
+	store = instrumented.New(store, reg)
+
+	deps := map[string]readiness.Pinger{"storage": store}
+	// Reads do not need the music info API, so it never fails readiness.
+	optionalDeps := map[string]readiness.Pinger{}
+
+	var infoFetcher add.SongInfoFetcher
+	if cfg.MusicInfo.URL != "" {
+		client := musicinfo.New(cfg.MusicInfo.URL, cfg.MusicInfo.Timeout)
+		infoFetcher = client
+		optionalDeps["music_info"] = client
+
+		log.Info("song enrichment enabled", slog.String("url", cfg.MusicInfo.URL))
+	}
+
+	var draining atomic.Bool
 
 	router := chi.NewRouter()
 
 	router.Use(middleware.RequestID)
 	router.Use(mwLogger.New(log))
+	router.Use(mwMetrics.New(reg))
 	router.Use(middleware.Recoverer)
 	router.Use(middleware.URLFormat)
+	router.Use(mwAuthor.New())
 
 	router.Route("/songs", func(r chi.Router) {
-		r.Get("/", getSongs.New(log, storage))                 // Список песен с фильтрацией и пагинацией
-		r.Get("/lyrics", getLyrics.New(log, storage))          // Текст песни с пагинацией по куплетам
-		r.Delete("/{group}/{song}", delete2.New(log, storage)) // Удаление песни
-		r.Put("/", update.New(log, storage))                   // Изменение данных песни
-		r.Post("/", add.New(log, storage))                     // Добавление новой песни
+		r.Get("/", getSongs.New(log, store))                 // Список песен с фильтрацией и пагинацией
+		r.Get("/lyrics", getLyrics.New(log, store))          // Текст песни с пагинацией по куплетам
+		r.Get("/search", search.New(log, store))             // Полнотекстовый поиск по текстам песен
+		r.Delete("/{group}/{song}", delete2.New(log, store)) // Удаление песни
+		r.Put("/", update.New(log, store))                   // Изменение данных песни
+		r.Patch("/", patch.New(log, store))                  // Частичное изменение (JSON Merge Patch)
+		r.Post("/", add.New(log, store, infoFetcher))        // Добавление новой песни
+
+		r.Get("/{id}", getSongs.NewByID(log, store))
+		r.Get("/{id}/lyrics", getLyrics.NewByID(log, store))
+		r.Get("/{id}/lyrics/diff", lyricsDiff.New(log, store))
+		r.Put("/{id}", update.NewByID(log, store))
+		r.Patch("/{id}", patch.NewByID(log, store))
+		r.Delete("/{id}", delete2.NewByID(log, store))
+		r.Post("/{id}/tags", songTags.NewAttach(log, store, storage.KindTag))
+		r.Delete("/{id}/tags/{name}", songTags.NewDetach(log, store, storage.KindTag))
+		r.Post("/{id}/genres", songTags.NewAttach(log, store, storage.KindGenre))
+		r.Delete("/{id}/genres/{name}", songTags.NewDetach(log, store, storage.KindGenre))
+		r.Get("/{id}/revisions", revisions.New(log, store))
+		r.Get("/{id}/revisions/{rev}", revisions.NewByNumber(log, store))
+		r.Post("/{id}/revisions/{rev}/restore", revisions.NewRestore(log, store))
+	})
+
+	router.Route("/artists", func(r chi.Router) {
+		r.Get("/", getArtists.New(log, store))
+		r.Post("/", addArtist.New(log, store))
+		r.Get("/{id}", getArtists.NewByID(log, store))
+		r.Put("/{id}", updateArtist.New(log, store))
+		r.Delete("/{id}", deleteArtist.New(log, store))
+		r.Get("/{id}/songs", getSongs.NewByArtist(log, store))
+		r.Post("/{id}/merge", mergeArtists.New(log, store))
+	})
+
+	router.Route("/albums", func(r chi.Router) {
+		r.Get("/", getAlbums.New(log, store))
+		r.Post("/", addAlbum.New(log, store))
+		r.Get("/{id}", getAlbums.NewByID(log, store))
+		r.Put("/{id}", updateAlbum.New(log, store))
+		r.Delete("/{id}", deleteAlbum.New(log, store))
+	})
+
+	router.Route("/trash", func(r chi.Router) {
+		r.Get("/", trash.New(log, store))
+		r.Post("/{id}/restore", trash.NewRestore(log, store))
 	})
 
-	router.Get("/info", info.New(log, storage))
+	router.Get("/info", info.New(log, store))
+	router.Get("/suggest", suggest.New(log, store))
+	router.Get("/tags", tags.New(log, store, storage.KindTag))
+	router.Get("/genres", tags.New(log, store, storage.KindGenre))
+
+	router.Get("/healthz", liveness.New())
+	router.Get("/readyz", readiness.New(log, &draining, deps, optionalDeps))
+	router.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
 
 	router.Get("/swagger/*", httpSwagger.Handler(
 		httpSwagger.URL("http://localhost:8082/swagger/doc.json"),
@@ -86,11 +212,65 @@ func main() {
 		IdleTimeout:  cfg.HTTPServer.IdleTimeout,
 	}
 
-	if err := srv.ListenAndServe(); err != nil {
-		log.Error("failed to start server")
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var purging sync.WaitGroup
+	if cfg.Trash.Retention > 0 {
+		purging.Add(1)
+		go func() {
+			defer purging.Done()
+			purger.Run(ctx, log, store, cfg.Trash.Retention, cfg.Trash.PurgeInterval)
+		}()
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+
+	var failed bool
+	select {
+	case <-ctx.Done():
+		log.Info("stopping server")
+
+		// Failing /readyz first gives load balancers time to stop routing here.
+		draining.Store(true)
+		time.Sleep(cfg.HTTPServer.DrainDelay)
+	case err := <-serverErr:
+		log.Error("failed to start server", sl.Err(err))
+		failed = true
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTPServer.ShutdownTimeout)
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Error("failed to drain connections", sl.Err(err))
 	}
+	cancel()
 
-	log.Error("server stopped")
+	stop()
+	purging.Wait()
+
+	store.Close()
+
+	log.Info("server stopped")
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func setupStorage(cfg *config.Config) (storage.Storage, error) {
+	opts := storage.Options{NormalizeArtistNames: cfg.NormalizeArtistNames}
+
+	switch cfg.Storage {
+	case config.StorageMemory:
+		return memory.New(opts), nil
+	default:
+		return postgres.New(cfg.ConnectionString, opts)
+	}
 }
 
 func setupLogger(env string) *slog.Logger {
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"song-library/internal/config"
+	"song-library/internal/migrator"
+	"song-library/migrations"
+)
+
+const migrateUsage = "usage: migrate up|down|status|to N"
+
+// runMigrate implements the "migrate" subcommand.
+func runMigrate(ctx context.Context, log *slog.Logger, cfg *config.Config, args []string) error {
+	if cfg.Storage != config.StoragePostgres {
+		return fmt.Errorf("migrations require %s storage", config.StoragePostgres)
+	}
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	m, err := migrator.New(ctx, cfg.ConnectionString, migrations.FS)
+	if err != nil {
+		return err
+	}
+	defer m.Close(ctx)
+
+	switch args[0] {
+	case "up":
+		applied, err := m.Up(ctx)
+		for _, version := range applied {
+			log.Info("migration applied", slog.Int("version", version))
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			log.Info("schema is up to date")
+		}
+	case "down":
+		version, err := m.Down(ctx)
+		if err != nil {
+			return err
+		}
+		log.Info("migration reverted", slog.Int("version", version))
+	case "to":
+		if len(args) != 2 {
+			return errors.New(migrateUsage)
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		if err := m.To(ctx, version); err != nil {
+			return err
+		}
+		log.Info("schema migrated", slog.Int("version", version))
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, st := range statuses {
+			applied := "pending"
+			if st.Applied {
+				applied = "applied " + st.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%d\t%s\t%s\n", st.Version, st.Name, applied)
+		}
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	return nil
+}
+
+// migrateOnStart applies pending migrations before the server starts.
+func migrateOnStart(ctx context.Context, log *slog.Logger, cfg *config.Config) error {
+	m, err := migrator.New(ctx, cfg.ConnectionString, migrations.FS)
+	if err != nil {
+		return err
+	}
+	defer m.Close(ctx)
+
+	applied, err := m.Up(ctx)
+	for _, version := range applied {
+		log.Info("migration applied", slog.Int("version", version))
+	}
+
+	return err
+}
@@ -0,0 +1,106 @@
+package musicinfo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"song-library/internal/models"
+)
+
+var (
+	ErrBadRequest  = errors.New("music info: bad request")
+	ErrNotFound    = errors.New("music info: song not found")
+	ErrUnavailable = errors.New("music info: service unavailable")
+	ErrBadResponse = errors.New("music info: malformed response")
+)
+
+// releaseDateLayout is the format of release_date in upstream responses.
+const releaseDateLayout = "02.01.2006"
+
+// Client fetches song details from the external music info API.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func New(baseURL string, timeout time.Duration) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Ping checks that the upstream API answers. Any response below 500,
+// including 400 for the missing query parameters, counts as available.
+func (c *Client) Ping(ctx context.Context) error {
+	const op = "clients.musicinfo.Ping"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/info", nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w: %w", op, ErrUnavailable, err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%s: %w: status %d", op, ErrUnavailable, res.StatusCode)
+	}
+
+	return nil
+}
+
+// Info calls GET /info?group=&song= on the upstream API. A body that does
+// not decode or carries an unparsable release_date yields ErrBadResponse.
+func (c *Client) Info(ctx context.Context, group, song string) (*models.SongDetail, error) {
+	const op = "clients.musicinfo.Info"
+
+	query := url.Values{}
+	query.Set("group", group)
+	query.Set("song", song)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/info?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrUnavailable, err)
+	}
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode == http.StatusOK:
+	case res.StatusCode == http.StatusBadRequest:
+		return nil, fmt.Errorf("%s: %w", op, ErrBadRequest)
+	case res.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%s: %w", op, ErrNotFound)
+	case res.StatusCode >= http.StatusInternalServerError:
+		return nil, fmt.Errorf("%s: %w: status %d", op, ErrUnavailable, res.StatusCode)
+	default:
+		return nil, fmt.Errorf("%s: unexpected status %d", op, res.StatusCode)
+	}
+
+	var detail models.SongDetail
+	if err := json.NewDecoder(res.Body).Decode(&detail); err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", op, ErrBadResponse, err)
+	}
+	if detail.ReleaseDate != "" {
+		if _, err := time.Parse(releaseDateLayout, detail.ReleaseDate); err != nil {
+			return nil, fmt.Errorf("%s: %w: release date: %w", op, ErrBadResponse, err)
+		}
+	}
+
+	return &detail, nil
+}
@@ -0,0 +1,62 @@
+package musicinfo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		delay   time.Duration
+		wantErr error
+	}{
+		{
+			name:   "ok",
+			status: http.StatusOK,
+			body:   `{"release_date":"16.07.2006","text":"Ooh baby","link":"https://example.com"}`,
+		},
+		{name: "bad request", status: http.StatusBadRequest, wantErr: ErrBadRequest},
+		{name: "not found", status: http.StatusNotFound, wantErr: ErrNotFound},
+		{name: "server error", status: http.StatusInternalServerError, wantErr: ErrUnavailable},
+		{name: "bad gateway", status: http.StatusBadGateway, wantErr: ErrUnavailable},
+		{name: "timeout", status: http.StatusOK, body: `{}`, delay: time.Second, wantErr: ErrUnavailable},
+		{name: "malformed body", status: http.StatusOK, body: `{"text":`, wantErr: ErrBadResponse},
+		{name: "malformed release date", status: http.StatusOK, body: `{"release_date":"2006-07-16"}`, wantErr: ErrBadResponse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/info" || r.URL.Query().Get("group") != "Muse" || r.URL.Query().Get("song") != "Supermassive Black Hole" {
+					t.Errorf("unexpected request %s", r.URL)
+				}
+				select {
+				case <-time.After(tt.delay):
+				case <-r.Context().Done():
+					return
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			detail, err := New(srv.URL+"/", 100*time.Millisecond).Info(context.Background(), "Muse", "Supermassive Black Hole")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Info error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if detail.ReleaseDate != "16.07.2006" || detail.Text != "Ooh baby" || detail.Link != "https://example.com" {
+				t.Errorf("Info = %+v", detail)
+			}
+		})
+	}
+}
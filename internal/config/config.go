@@ -7,16 +7,49 @@ import (
 	"github.com/joho/godotenv"
 )
 
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+)
+
 type Config struct {
-	Env              string `env:"ENV" env-default:"local"`
-	ConnectionString string `env:"CONNECTION_STRING,required"`
+	Env              string `env:"ENV" envDefault:"local"`
+	Storage          string `env:"STORAGE" envDefault:"postgres"`
+	ConnectionString string `env:"CONNECTION_STRING"`
+	MigrateOnStart   bool   `env:"MIGRATE_ON_START" envDefault:"true"`
+	// NormalizeArtistNames matches artists ignoring case and whitespace on insert.
+	NormalizeArtistNames bool `env:"NORMALIZE_ARTIST_NAMES" envDefault:"false"`
 	HTTPServer
+	MusicInfo
+	Trash
 }
 
 type HTTPServer struct {
-	Address     string        `env:"HTTP_SERVER_ADDRESS" env-default:"localhost:8080"`
-	Timeout     time.Duration `env:"HTTP_SERVER_TIMEOUT" env-default:"4s"`
-	IdleTimeout time.Duration `env:"HTTP_SERVER_IDLE_TIMEOUT" env-default:"60s"`
+	Address     string        `env:"HTTP_SERVER_ADDRESS" envDefault:"localhost:8080"`
+	Timeout     time.Duration `env:"HTTP_SERVER_TIMEOUT" envDefault:"4s"`
+	IdleTimeout time.Duration `env:"HTTP_SERVER_IDLE_TIMEOUT" envDefault:"60s"`
+	// DrainDelay is how long /readyz reports draining before the server stops
+	// accepting connections.
+	DrainDelay time.Duration `env:"HTTP_SERVER_DRAIN_DELAY" envDefault:"5s"`
+	// ShutdownTimeout limits how long in-flight requests may drain on shutdown.
+	ShutdownTimeout time.Duration `env:"HTTP_SERVER_SHUTDOWN_TIMEOUT" envDefault:"10s"`
+}
+
+// MusicInfo configures the external API used to enrich new songs.
+// Enrichment is disabled when URL is empty.
+type MusicInfo struct {
+	URL     string        `env:"MUSIC_INFO_URL"`
+	Timeout time.Duration `env:"MUSIC_INFO_TIMEOUT" envDefault:"5s"`
+}
+
+// Trash configures how long deleted songs can be restored.
+type Trash struct {
+	// Retention is how long songs stay in the trash before they are purged
+	// for good; 0 keeps them until they are restored.
+	Retention time.Duration `env:"TRASH_RETENTION" envDefault:"720h"`
+	// PurgeInterval is how often the trash is checked; it must be positive
+	// unless Retention is 0.
+	PurgeInterval time.Duration `env:"TRASH_PURGE_INTERVAL" envDefault:"1h"`
 }
 
 func MustLoad() *Config {
@@ -30,5 +63,19 @@ func MustLoad() *Config {
 		panic("failed to parse environment variables: " + err.Error())
 	}
 
+	switch cfg.Storage {
+	case StoragePostgres:
+		if cfg.ConnectionString == "" {
+			panic("CONNECTION_STRING is required for postgres storage")
+		}
+	case StorageMemory:
+	default:
+		panic("unknown storage: " + cfg.Storage)
+	}
+
+	if cfg.Trash.Retention > 0 && cfg.Trash.PurgeInterval <= 0 {
+		panic("TRASH_PURGE_INTERVAL must be positive while TRASH_RETENTION is set")
+	}
+
 	return &cfg
 }
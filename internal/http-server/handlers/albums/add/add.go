@@ -0,0 +1,136 @@
+package add
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"song-library/internal/lib/api/resp"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type AlbumSaver interface {
+	AddAlbum(ctx context.Context, album *storage.Album) error
+}
+
+// @Summary Add album
+// @Description Creates an album with its track listing. The artist is created if needed; tracks refer to existing songs by ID.
+// @Accept  json
+// @Tags albums
+// @Produce  json
+// @Param   request  body models.AlbumRequest true "Album"
+// @Success 201 {object} models.Album  "Album created"
+// @Failure 400 {object} resp.Response  "Bad request"
+// @Failure 500 {object} resp.Response  "Internal server error"
+// @Router /albums [post]
+func New(log *slog.Logger, albumSaver AlbumSaver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.albums.add.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req models.AlbumRequest
+
+		err := render.DecodeJSON(r.Body, &req)
+		if errors.Is(err, io.EOF) {
+			log.Error("request body is empty")
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("empty request"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("invalid request body"))
+
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		album := storage.Album{
+			Artist:    req.Artist,
+			Title:     req.Title,
+			CoverLink: req.CoverLink,
+		}
+
+		if req.ReleaseDate != "" {
+			releaseDate, err := time.Parse("02.01.2006", req.ReleaseDate)
+			if err != nil {
+				log.Error("failed to parse album release date", sl.Err(err))
+
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("bad release date"))
+
+				return
+			}
+			album.ReleaseDate = releaseDate
+		}
+
+		for _, track := range req.Tracks {
+			album.Tracks = append(album.Tracks, storage.Track{Number: track.Number, SongID: track.SongID})
+		}
+
+		err = albumSaver.AddAlbum(r.Context(), &album)
+		if errors.Is(err, storage.ErrInvalidTrack) {
+			log.Error("invalid track listing", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("track numbers and songs must not repeat"))
+
+			return
+		}
+		if errors.Is(err, storage.ErrTrackSongNotFound) {
+			log.Error("track song not found", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("track song not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to add album", sl.Err(err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("album added", slog.Int("id", album.ID))
+
+		w.WriteHeader(http.StatusCreated)
+		render.JSON(w, r, models.Album{
+			ID:          album.ID,
+			Artist:      album.Artist,
+			Title:       album.Title,
+			ReleaseDate: req.ReleaseDate,
+			CoverLink:   album.CoverLink,
+			TrackCount:  len(album.Tracks),
+		})
+	}
+}
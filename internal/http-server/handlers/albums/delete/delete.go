@@ -0,0 +1,74 @@
+package delete
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"song-library/internal/lib/api/param"
+	"song-library/internal/lib/api/resp"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type AlbumRemover interface {
+	DeleteAlbum(ctx context.Context, id int) error
+}
+
+// @Summary Delete an album.
+// @Description Deletes the album and its track listing. The songs stay.
+// @Tags albums
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Album ID" Example(1)
+// @Success 200 {object} resp.Response "Album deleted"
+// @Failure 400 {object} resp.Response "Bad Request - Invalid ID"
+// @Failure 404 {object} resp.Response "Not Found - Album not found"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /albums/{id} [delete]
+func New(log *slog.Logger, remover AlbumRemover) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.albums.delete.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := param.ID(r)
+		if err != nil {
+			log.Error("invalid album id", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("invalid album id"))
+
+			return
+		}
+
+		err = remover.DeleteAlbum(r.Context(), id)
+		if errors.Is(err, storage.ErrAlbumNotFound) {
+			log.Error("album not found", sl.Err(err))
+
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, resp.Error("album not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to delete album", sl.Err(err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("album deleted", slog.Int("id", id))
+
+		render.JSON(w, r, resp.OK())
+	}
+}
@@ -0,0 +1,74 @@
+package get
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"song-library/internal/lib/api/param"
+	"song-library/internal/lib/api/resp"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type AlbumGetter interface {
+	GetAlbum(ctx context.Context, id int) (*storage.Album, error)
+}
+
+// @Summary Get an album by ID.
+// @Description Fetches an album with its track listing.
+// @Tags albums
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Album ID" Example(1)
+// @Success 200 {object} models.Album "Album"
+// @Failure 400 {object} resp.Response "Bad Request - Invalid ID"
+// @Failure 404 {object} resp.Response "Not Found - Album not found"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /albums/{id} [get]
+func NewByID(log *slog.Logger, albumGetter AlbumGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.albums.get.NewByID"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := param.ID(r)
+		if err != nil {
+			log.Error("invalid album id", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("invalid album id"))
+
+			return
+		}
+
+		album, err := albumGetter.GetAlbum(r.Context(), id)
+		if errors.Is(err, storage.ErrAlbumNotFound) {
+			log.Error("album not found", sl.Err(err))
+
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, resp.Error("album not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to get album", sl.Err(err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Debug("album found", slog.Int("id", id))
+
+		render.JSON(w, r, formatAlbum(album))
+	}
+}
@@ -0,0 +1,94 @@
+package get
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"song-library/internal/lib/api/resp"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type AlbumsLister interface {
+	ListAlbums(ctx context.Context, limit, offset int) ([]*storage.Album, error)
+}
+
+// @Summary List albums.
+// @Description Lists albums ordered by ID, each with the number of its tracks.
+// @Tags albums
+// @Accept  json
+// @Produce  json
+// @Param limit query int false "Limit of albums to retrieve" Default(10)
+// @Param offset query int false "Offset for pagination" Default(0)
+// @Success 200 {array} models.Album "A list of albums"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /albums [get]
+func New(log *slog.Logger, lister AlbumsLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.albums.get.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil || limit <= 0 {
+			limit = 10
+		}
+		offset, err := strconv.Atoi(r.URL.Query().Get("offset"))
+		if err != nil || offset < 0 {
+			offset = 0
+		}
+
+		albums, err := lister.ListAlbums(r.Context(), limit, offset)
+		if err != nil {
+			log.Error("failed to list albums", sl.Err(err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		response := make([]*models.Album, len(albums))
+		for i, album := range albums {
+			response[i] = formatAlbum(album)
+		}
+
+		log.Debug("albums fetched", slog.Int("limit", limit), slog.Int("offset", offset))
+
+		render.JSON(w, r, response)
+	}
+}
+
+func formatAlbum(album *storage.Album) *models.Album {
+	releaseDate := ""
+	if !album.ReleaseDate.IsZero() {
+		releaseDate = album.ReleaseDate.Format("02.01.2006")
+	}
+
+	res := &models.Album{
+		ID:          album.ID,
+		Artist:      album.Artist,
+		Title:       album.Title,
+		ReleaseDate: releaseDate,
+		CoverLink:   album.CoverLink,
+		TrackCount:  album.TrackCount,
+	}
+	for _, track := range album.Tracks {
+		res.Tracks = append(res.Tracks, &models.Track{
+			Number: track.Number,
+			SongID: track.SongID,
+			Artist: track.Artist,
+			Title:  track.Title,
+		})
+	}
+	return res
+}
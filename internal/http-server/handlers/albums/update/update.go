@@ -0,0 +1,150 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"song-library/internal/lib/api/param"
+	"song-library/internal/lib/api/resp"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type AlbumUpdater interface {
+	UpdateAlbum(ctx context.Context, album *storage.Album) error
+}
+
+// @Summary Replace an album.
+// @Description Replaces the album, track listing included. Fields left out are cleared.
+// @Tags albums
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Album ID" Example(1)
+// @Param request body models.AlbumRequest true "New album"
+// @Success 200 {object} resp.Response "Album updated"
+// @Failure 400 {object} resp.Response "Bad Request"
+// @Failure 404 {object} resp.Response "Not Found - Album not found"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /albums/{id} [put]
+func New(log *slog.Logger, updater AlbumUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.albums.update.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := param.ID(r)
+		if err != nil {
+			log.Error("invalid album id", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("invalid album id"))
+
+			return
+		}
+
+		var req models.AlbumRequest
+
+		err = render.DecodeJSON(r.Body, &req)
+		if errors.Is(err, io.EOF) {
+			log.Error("request body is empty")
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("empty request"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("invalid request body"))
+
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		album := storage.Album{
+			ID:        id,
+			Artist:    req.Artist,
+			Title:     req.Title,
+			CoverLink: req.CoverLink,
+		}
+
+		if req.ReleaseDate != "" {
+			releaseDate, err := time.Parse("02.01.2006", req.ReleaseDate)
+			if err != nil {
+				log.Error("failed to parse album release date", sl.Err(err))
+
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("bad release date"))
+
+				return
+			}
+			album.ReleaseDate = releaseDate
+		}
+
+		for _, track := range req.Tracks {
+			album.Tracks = append(album.Tracks, storage.Track{Number: track.Number, SongID: track.SongID})
+		}
+
+		err = updater.UpdateAlbum(r.Context(), &album)
+		if errors.Is(err, storage.ErrAlbumNotFound) {
+			log.Error("album not found", sl.Err(err))
+
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, resp.Error("album not found"))
+
+			return
+		}
+		if errors.Is(err, storage.ErrInvalidTrack) {
+			log.Error("invalid track listing", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("track numbers and songs must not repeat"))
+
+			return
+		}
+		if errors.Is(err, storage.ErrTrackSongNotFound) {
+			log.Error("track song not found", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("track song not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to update album", sl.Err(err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("album updated", slog.Int("id", id))
+
+		render.JSON(w, r, resp.OK())
+	}
+}
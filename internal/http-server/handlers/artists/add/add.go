@@ -0,0 +1,100 @@
+package add
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"song-library/internal/lib/api/resp"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type ArtistSaver interface {
+	AddArtist(ctx context.Context, artist *storage.Artist) error
+}
+
+// @Summary Add artist
+// @Description Creates an artist without songs.
+// @Accept  json
+// @Tags artists
+// @Produce  json
+// @Param   request  body models.ArtistRequest true "Artist"
+// @Success 201 {object} models.Artist  "Artist created"
+// @Failure 400 {object} resp.Response  "Bad request"
+// @Failure 409 {object} resp.Response  "Artist already exists"
+// @Failure 500 {object} resp.Response  "Internal server error"
+// @Router /artists [post]
+func New(log *slog.Logger, artistSaver ArtistSaver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.artists.add.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req models.ArtistRequest
+
+		err := render.DecodeJSON(r.Body, &req)
+		if errors.Is(err, io.EOF) {
+			log.Error("request body is empty")
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("empty request"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("invalid request body"))
+
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		artist := storage.Artist{Name: req.Name}
+
+		err = artistSaver.AddArtist(r.Context(), &artist)
+		if errors.Is(err, storage.ErrArtistExists) {
+			log.Error("artist already exists", sl.Err(err))
+
+			w.WriteHeader(http.StatusConflict)
+			render.JSON(w, r, resp.Error("artist already exists"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to add artist", sl.Err(err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("artist added", slog.Int("id", artist.ID))
+
+		w.WriteHeader(http.StatusCreated)
+		render.JSON(w, r, models.Artist{ID: artist.ID, Name: artist.Name})
+	}
+}
@@ -0,0 +1,97 @@
+package delete
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"song-library/internal/lib/api/param"
+	"song-library/internal/lib/api/resp"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type ArtistRemover interface {
+	DeleteArtist(ctx context.Context, id int, cascade bool) error
+}
+
+// @Summary Delete an artist.
+// @Description Deletes the artist and its albums. It is refused while the artist has songs, those in the trash included, unless cascade=true also deletes them for good; their revisions remain.
+// @Tags artists
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Artist ID" Example(1)
+// @Param cascade query bool false "Delete the artist's songs too" Default(false)
+// @Success 200 {object} resp.Response "Artist deleted"
+// @Failure 400 {object} resp.Response "Bad Request"
+// @Failure 404 {object} resp.Response "Not Found - Artist not found"
+// @Failure 409 {object} resp.Response "Conflict - Artist has songs"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /artists/{id} [delete]
+func New(log *slog.Logger, remover ArtistRemover) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.artists.delete.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := param.ID(r)
+		if err != nil {
+			log.Error("invalid artist id", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("invalid artist id"))
+
+			return
+		}
+
+		cascade := false
+		if value := r.URL.Query().Get("cascade"); value != "" {
+			if cascade, err = strconv.ParseBool(value); err != nil {
+				log.Error("invalid cascade flag", sl.Err(err))
+
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("cascade must be true or false"))
+
+				return
+			}
+		}
+
+		err = remover.DeleteArtist(r.Context(), id, cascade)
+		if errors.Is(err, storage.ErrArtistNotFound) {
+			log.Error("artist not found", sl.Err(err))
+
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, resp.Error("artist not found"))
+
+			return
+		}
+		if errors.Is(err, storage.ErrArtistHasSongs) {
+			log.Info("artist has songs", sl.Err(err))
+
+			w.WriteHeader(http.StatusConflict)
+			render.JSON(w, r, resp.Error("artist has songs, pass cascade=true to delete them too"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to delete artist", sl.Err(err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("artist deleted", slog.Int("id", id), slog.Bool("cascade", cascade))
+
+		render.JSON(w, r, resp.OK())
+	}
+}
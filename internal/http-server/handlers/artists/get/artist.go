@@ -0,0 +1,74 @@
+package get
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"song-library/internal/lib/api/param"
+	"song-library/internal/lib/api/resp"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type ArtistGetter interface {
+	GetArtist(ctx context.Context, id int) (*storage.Artist, error)
+}
+
+// @Summary Get an artist by ID.
+// @Description Fetches an artist with the number of its songs.
+// @Tags artists
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Artist ID" Example(1)
+// @Success 200 {object} models.Artist "Artist"
+// @Failure 400 {object} resp.Response "Bad Request - Invalid ID"
+// @Failure 404 {object} resp.Response "Not Found - Artist not found"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /artists/{id} [get]
+func NewByID(log *slog.Logger, artistGetter ArtistGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.artists.get.NewByID"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := param.ID(r)
+		if err != nil {
+			log.Error("invalid artist id", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("invalid artist id"))
+
+			return
+		}
+
+		artist, err := artistGetter.GetArtist(r.Context(), id)
+		if errors.Is(err, storage.ErrArtistNotFound) {
+			log.Error("artist not found", sl.Err(err))
+
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, resp.Error("artist not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to get artist", sl.Err(err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Debug("artist found", slog.Int("id", id))
+
+		render.JSON(w, r, formatArtist(artist))
+	}
+}
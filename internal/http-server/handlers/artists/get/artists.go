@@ -0,0 +1,77 @@
+package get
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"song-library/internal/lib/api/resp"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type ArtistsLister interface {
+	ListArtists(ctx context.Context, limit, offset int) ([]*storage.Artist, error)
+}
+
+// @Summary List artists.
+// @Description Lists artists ordered by ID, each with the number of its songs.
+// @Tags artists
+// @Accept  json
+// @Produce  json
+// @Param limit query int false "Limit of artists to retrieve" Default(10)
+// @Param offset query int false "Offset for pagination" Default(0)
+// @Success 200 {array} models.Artist "A list of artists"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /artists [get]
+func New(log *slog.Logger, lister ArtistsLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.artists.get.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil || limit <= 0 {
+			limit = 10
+		}
+		offset, err := strconv.Atoi(r.URL.Query().Get("offset"))
+		if err != nil || offset < 0 {
+			offset = 0
+		}
+
+		artists, err := lister.ListArtists(r.Context(), limit, offset)
+		if err != nil {
+			log.Error("failed to list artists", sl.Err(err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		response := make([]*models.Artist, len(artists))
+		for i, artist := range artists {
+			response[i] = formatArtist(artist)
+		}
+
+		log.Debug("artists fetched", slog.Int("limit", limit), slog.Int("offset", offset))
+
+		render.JSON(w, r, response)
+	}
+}
+
+func formatArtist(artist *storage.Artist) *models.Artist {
+	return &models.Artist{
+		ID:        artist.ID,
+		Name:      artist.Name,
+		SongCount: artist.SongCount,
+	}
+}
@@ -0,0 +1,147 @@
+package merge
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"song-library/internal/lib/api/param"
+	"song-library/internal/lib/api/resp"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type ArtistMerger interface {
+	MergeArtists(ctx context.Context, targetID int, sourceIDs []int) (int, error)
+}
+
+// ConflictResponse lists the titles that kept a merge from happening.
+type ConflictResponse struct {
+	resp.Response
+	Conflicts []models.MergeConflict `json:"conflicts"`
+}
+
+// @Summary Merge duplicate artists.
+// @Description Moves the songs of the source artists to the artist in the path and deletes the sources, all at once.
+// @Description If a title exists under more than one of the artists, nothing changes and the conflicting songs are listed.
+// @Tags artists
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Target artist ID" Example(1)
+// @Param request body models.MergeRequest true "Artists to merge into the target"
+// @Success 200 {object} models.MergeResult "Artists merged"
+// @Failure 400 {object} resp.Response "Bad Request"
+// @Failure 404 {object} resp.Response "Not Found - Artist not found"
+// @Failure 409 {object} ConflictResponse "Conflict - Titles collide"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /artists/{id}/merge [post]
+func New(log *slog.Logger, merger ArtistMerger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.artists.merge.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := param.ID(r)
+		if err != nil {
+			log.Error("invalid artist id", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("invalid artist id"))
+
+			return
+		}
+
+		var req models.MergeRequest
+
+		err = render.DecodeJSON(r.Body, &req)
+		if errors.Is(err, io.EOF) {
+			log.Error("request body is empty")
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("empty request"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("invalid request body"))
+
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		seen := map[int]bool{id: true}
+		for _, sourceID := range req.SourceIDs {
+			if seen[sourceID] {
+				log.Error("repeated artist id", slog.Int("id", sourceID))
+
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("source_ids must be distinct and exclude the target"))
+
+				return
+			}
+			seen[sourceID] = true
+		}
+
+		moved, err := merger.MergeArtists(r.Context(), id, req.SourceIDs)
+		if errors.Is(err, storage.ErrArtistNotFound) {
+			log.Error("artist not found", sl.Err(err))
+
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, resp.Error("artist not found"))
+
+			return
+		}
+		var conflictErr *storage.MergeConflictError
+		if errors.As(err, &conflictErr) {
+			log.Error("merge conflict", sl.Err(err))
+
+			conflicts := make([]models.MergeConflict, len(conflictErr.Conflicts))
+			for i, c := range conflictErr.Conflicts {
+				conflicts[i] = models.MergeConflict{Title: c.Title, SongIDs: c.SongIDs}
+			}
+
+			w.WriteHeader(http.StatusConflict)
+			render.JSON(w, r, ConflictResponse{
+				Response:  resp.Error("artists have songs with the same title"),
+				Conflicts: conflicts,
+			})
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to merge artists", sl.Err(err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("artists merged", slog.Int("id", id), slog.Any("sources", req.SourceIDs), slog.Int("moved", moved))
+
+		render.JSON(w, r, models.MergeResult{Moved: moved})
+	}
+}
@@ -0,0 +1,118 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"song-library/internal/lib/api/param"
+	"song-library/internal/lib/api/resp"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type ArtistRenamer interface {
+	RenameArtist(ctx context.Context, id int, name string) error
+}
+
+// @Summary Rename an artist.
+// @Description Renames the artist; its songs follow. With NORMALIZE_ARTIST_NAMES the name is normalized and compared ignoring case and whitespace.
+// @Tags artists
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Artist ID" Example(1)
+// @Param request body models.ArtistRequest true "New name"
+// @Success 200 {object} resp.Response "Artist renamed"
+// @Failure 400 {object} resp.Response "Bad Request"
+// @Failure 404 {object} resp.Response "Not Found - Artist not found"
+// @Failure 409 {object} resp.Response "Conflict - Another artist has this name"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /artists/{id} [put]
+func New(log *slog.Logger, renamer ArtistRenamer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.artists.update.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := param.ID(r)
+		if err != nil {
+			log.Error("invalid artist id", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("invalid artist id"))
+
+			return
+		}
+
+		var req models.ArtistRequest
+
+		err = render.DecodeJSON(r.Body, &req)
+		if errors.Is(err, io.EOF) {
+			log.Error("request body is empty")
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("empty request"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("invalid request body"))
+
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		err = renamer.RenameArtist(r.Context(), id, req.Name)
+		if errors.Is(err, storage.ErrArtistNotFound) {
+			log.Error("artist not found", sl.Err(err))
+
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, resp.Error("artist not found"))
+
+			return
+		}
+		if errors.Is(err, storage.ErrArtistExists) {
+			log.Error("artist name taken", sl.Err(err))
+
+			w.WriteHeader(http.StatusConflict)
+			render.JSON(w, r, resp.Error("artist already exists"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to rename artist", sl.Err(err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Info("artist renamed", slog.Int("id", id))
+
+		render.JSON(w, r, resp.OK())
+	}
+}
@@ -0,0 +1,21 @@
+package liveness
+
+import (
+	"net/http"
+
+	"song-library/internal/lib/api/resp"
+
+	"github.com/go-chi/render"
+)
+
+// @Summary Liveness probe
+// @Description Reports that the process is running. It does not check dependencies.
+// @Tags health
+// @Produce  json
+// @Success 200 {object} resp.Response "Process is alive"
+// @Router /healthz [get]
+func New() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		render.JSON(w, r, resp.OK())
+	}
+}
@@ -0,0 +1,96 @@
+package readiness
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"song-library/internal/lib/api/resp"
+
+	"github.com/go-chi/render"
+)
+
+// checkTimeout bounds each dependency check.
+const checkTimeout = 2 * time.Second
+
+// Statuses share the casing of resp.Response, which /healthz returns.
+const (
+	StatusOK          = resp.StatusOk
+	StatusUnavailable = "Unavailable"
+	StatusDraining    = "Draining"
+)
+
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+type Check struct {
+	Status string `json:"status" example:"OK"`
+	Error  string `json:"error,omitempty"`
+}
+
+type Response struct {
+	Status string           `json:"status" example:"OK"`
+	Checks map[string]Check `json:"checks"`
+}
+
+// @Summary Readiness probe
+// @Description Checks every dependency (storage and, if configured, the music info API) and reports per-dependency status. Returns 503 if a required check fails or the server is shutting down.
+// @Description Optional dependencies, such as the music info API, are reported but do not fail the probe.
+// @Tags health
+// @Produce  json
+// @Success 200 {object} readiness.Response "Ready to serve traffic"
+// @Failure 503 {object} readiness.Response "Not ready"
+// @Router /readyz [get]
+func New(log *slog.Logger, draining *atomic.Bool, required, optional map[string]Pinger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.health.readiness.New"
+
+		log := log.With(slog.String("op", op))
+
+		res := Response{
+			Status: StatusOK,
+			Checks: make(map[string]Check, len(required)+len(optional)),
+		}
+
+		for name, dep := range required {
+			check := ping(r.Context(), dep)
+			if check.Status != StatusOK {
+				log.Error("dependency check failed", slog.String("dependency", name), slog.String("error", check.Error))
+
+				res.Status = StatusUnavailable
+			}
+			res.Checks[name] = check
+		}
+
+		for name, dep := range optional {
+			check := ping(r.Context(), dep)
+			if check.Status != StatusOK {
+				log.Warn("optional dependency check failed", slog.String("dependency", name), slog.String("error", check.Error))
+			}
+			res.Checks[name] = check
+		}
+
+		if draining.Load() {
+			res.Status = StatusDraining
+		}
+
+		if res.Status != StatusOK {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		render.JSON(w, r, res)
+	}
+}
+
+func ping(ctx context.Context, dep Pinger) Check {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	if err := dep.Ping(ctx); err != nil {
+		return Check{Status: StatusUnavailable, Error: err.Error()}
+	}
+	return Check{Status: StatusOK}
+}
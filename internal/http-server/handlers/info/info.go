@@ -25,7 +25,8 @@ type SongGetter interface {
 }
 
 // @Summary Get song detail
-// @Description Fetches the details of a song given an artist's name and song title.
+// @Description Fetches the details of a song given an artist's name and song title, with the albums it is on.
+// @Description A song without a release date of its own takes that of its earliest dated album.
 // @Tags songs
 // @Accept  json
 // @Produce  json
@@ -84,6 +85,13 @@ func New(log *slog.Logger, songGetter SongGetter) http.HandlerFunc {
 			Text:        song.Lyrics,
 			Link:        song.Link,
 		}
+		for _, album := range song.Albums {
+			songAlbum := &models.SongAlbum{ID: album.ID, Title: album.Title, Track: album.Track}
+			if !album.ReleaseDate.IsZero() {
+				songAlbum.ReleaseDate = album.ReleaseDate.Format("02.01.2006")
+			}
+			songInfo.Albums = append(songInfo.Albums, songAlbum)
+		}
 		render.JSON(w, r, songInfo)
 
 	}
@@ -22,8 +22,13 @@ type SongSaver interface {
 	AddSong(ctx context.Context, song *storage.Song) error
 }
 
+type SongInfoFetcher interface {
+	Info(ctx context.Context, group, song string) (*models.SongDetail, error)
+}
+
 // @Summary Add song
-// @Description The request contains details about the song, including the artist's name, song title, release date, lyrics, and a link.
+// @Description The request contains details about the song, including the artist's name, song title, release date, lyrics, and a link. Artists credits featured artists, producers and composers besides the primary artist in group. If enrichment is enabled and the release date, lyrics or link is missing, they are fetched from the external music info API.
+// @Description Enrichment is optional: if the API rejects the request, does not know the song, fails, times out or answers with a malformed body, the song is stored as sent and the add still succeeds.
 // @Accept  json
 // @Tags songs
 // @Produce  json
@@ -33,11 +38,11 @@ type SongSaver interface {
 // @Failure 409 {object} resp.Response  "Song already exists"
 // @Failure 500 {object} resp.Response  "Internal server error"
 // @Router /songs [post]
-func New(log *slog.Logger, songSaver SongSaver) http.HandlerFunc {
+func New(log *slog.Logger, songSaver SongSaver, infoFetcher SongInfoFetcher) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.songs.add.New"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
@@ -74,11 +79,36 @@ func New(log *slog.Logger, songSaver SongSaver) http.HandlerFunc {
 			return
 		}
 
+		if infoFetcher != nil && (req.ReleaseDate == "" || req.Text == "" || req.Link == "") {
+			detail, err := infoFetcher.Info(r.Context(), req.Artist, req.Title)
+			if err != nil {
+				// Enrichment is optional: whatever the upstream failure, the song is stored as sent.
+				log.Warn("failed to fetch song info", sl.Err(err))
+			} else {
+				log.Debug("song info fetched", slog.Any("detail", detail))
+
+				if req.ReleaseDate == "" {
+					req.ReleaseDate = detail.ReleaseDate
+				}
+				if req.Text == "" {
+					req.Text = detail.Text
+				}
+				if req.Link == "" {
+					req.Link = detail.Link
+				}
+			}
+		}
+
 		song := storage.Song{
 			Artist: req.Artist,
 			Title:  req.Title,
 			Lyrics: req.Text,
 			Link:   req.Link,
+			Genres: req.Genres,
+			Tags:   req.Tags,
+		}
+		for _, c := range req.Artists {
+			song.Credits = append(song.Credits, storage.Credit{Artist: c.Name, Role: storage.ArtistRole(c.Role)})
 		}
 
 		if req.ReleaseDate != "" {
@@ -103,6 +133,22 @@ func New(log *slog.Logger, songSaver SongSaver) http.HandlerFunc {
 
 			return
 		}
+		if errors.Is(err, storage.ErrInvalidTag) {
+			log.Error("invalid tag", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.InvalidTag))
+
+			return
+		}
+		if errors.Is(err, storage.ErrInvalidCredit) {
+			log.Error("invalid credit", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.InvalidCredit))
+
+			return
+		}
 		if err != nil {
 			log.Error("failed to add song", sl.Err(err))
 
@@ -0,0 +1,99 @@
+package add
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"song-library/internal/clients/musicinfo"
+	"song-library/internal/storage"
+)
+
+type saver struct {
+	song *storage.Song
+}
+
+func (s *saver) AddSong(_ context.Context, song *storage.Song) error {
+	s.song = song
+	return nil
+}
+
+func TestNewEnrichment(t *testing.T) {
+	const detail = `{"release_date":"16.07.2006","text":"Ooh baby","link":"https://example.com"}`
+
+	tests := []struct {
+		name       string
+		body       string
+		status     int
+		upstream   string
+		delay      time.Duration
+		wantCalled bool
+		want       storage.Song
+	}{
+		{
+			name:       "fills missing fields",
+			body:       `{"group":"Muse","song":"Uprising","link":"https://mine"}`,
+			status:     http.StatusOK,
+			upstream:   detail,
+			wantCalled: true,
+			want:       storage.Song{ReleaseDate: time.Date(2006, time.July, 16, 0, 0, 0, 0, time.UTC), Lyrics: "Ooh baby", Link: "https://mine"},
+		},
+		{
+			name:     "complete song skips upstream",
+			body:     `{"group":"Muse","song":"Uprising","release_date":"07.09.2009","text":"Paranoia","link":"https://mine"}`,
+			status:   http.StatusInternalServerError,
+			upstream: "",
+			want:     storage.Song{ReleaseDate: time.Date(2009, time.September, 7, 0, 0, 0, 0, time.UTC), Lyrics: "Paranoia", Link: "https://mine"},
+		},
+		{name: "bad request", body: `{"group":"Muse","song":"Uprising"}`, status: http.StatusBadRequest, wantCalled: true},
+		{name: "not found", body: `{"group":"Muse","song":"Uprising"}`, status: http.StatusNotFound, wantCalled: true},
+		{name: "server error", body: `{"group":"Muse","song":"Uprising"}`, status: http.StatusBadGateway, wantCalled: true},
+		{name: "timeout", body: `{"group":"Muse","song":"Uprising"}`, status: http.StatusOK, upstream: detail, delay: time.Second, wantCalled: true},
+		{name: "malformed body", body: `{"group":"Muse","song":"Uprising"}`, status: http.StatusOK, upstream: `{"text":`, wantCalled: true},
+		{name: "malformed release date", body: `{"group":"Muse","song":"Uprising"}`, status: http.StatusOK, upstream: `{"release_date":"2006-07-16","text":"Ooh baby"}`, wantCalled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var called atomic.Bool
+			upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called.Store(true)
+				select {
+				case <-time.After(tt.delay):
+				case <-r.Context().Done():
+					return
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.upstream))
+			}))
+			defer upstream.Close()
+
+			songs := &saver{}
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+			handler := New(log, songs, musicinfo.New(upstream.URL, 100*time.Millisecond))
+
+			rec := httptest.NewRecorder()
+			handler(rec, httptest.NewRequest(http.MethodPost, "/songs", strings.NewReader(tt.body)))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, body %s; want 200", rec.Code, rec.Body)
+			}
+			if called.Load() != tt.wantCalled {
+				t.Errorf("upstream called = %v, want %v", called.Load(), tt.wantCalled)
+			}
+			got := songs.song
+			if got == nil || got.Artist != "Muse" || got.Title != "Uprising" {
+				t.Fatalf("saved song = %+v", got)
+			}
+			if !got.ReleaseDate.Equal(tt.want.ReleaseDate) || got.Lyrics != tt.want.Lyrics || got.Link != tt.want.Link {
+				t.Errorf("saved song = %+v, want release date %v, lyrics %q, link %q", got, tt.want.ReleaseDate, tt.want.Lyrics, tt.want.Link)
+			}
+		})
+	}
+}
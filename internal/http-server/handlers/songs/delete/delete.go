@@ -20,7 +20,7 @@ type SongRemover interface {
 }
 
 // @Summary Delete a song by artist and title.
-// @Description Deletes the specified song by artist and title from the database. Requires both "group" and "song" path parameters.
+// @Description Moves the specified song by artist and title to the trash, from where POST /trash/{id}/restore brings it back until it is purged. Requires both "group" and "song" path parameters.
 // @Tags songs
 // @Accept  json
 // @Produce  json
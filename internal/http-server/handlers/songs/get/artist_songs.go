@@ -0,0 +1,107 @@
+package get
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"song-library/internal/lib/api/param"
+	"song-library/internal/lib/api/resp"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type ArtistSongsGetter interface {
+	GetArtist(ctx context.Context, id int) (*storage.Artist, error)
+	GetSongs(ctx context.Context, filter *storage.SongFilter, page storage.Page) ([]*storage.Song, error)
+}
+
+// @Summary List the songs of an artist.
+// @Description Fetches the songs of the artist with the given ID.
+// @Tags artists
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Artist ID" Example(1)
+// @Param sort query string false "Comma-separated sort keys: id, artist, title, release_date. Prefix with '-' for descending order" Example("-release_date")
+// @Param limit query int false "Limit of songs to retrieve" Default(10)
+// @Param offset query int false "Offset for pagination" Default(0)
+// @Success 200 {array} models.Song "Songs of the artist"
+// @Failure 400 {object} resp.Response "Bad Request"
+// @Failure 404 {object} resp.Response "Not Found - Artist not found"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /artists/{id}/songs [get]
+func NewByArtist(log *slog.Logger, songsGetter ArtistSongsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.songs.get.NewByArtist"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := param.ID(r)
+		if err != nil {
+			log.Error("invalid artist id", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("invalid artist id"))
+
+			return
+		}
+
+		sort, err := storage.ParseSort(r.URL.Query().Get("sort"))
+		if err != nil {
+			log.Info("invalid sort", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(err.Error()))
+
+			return
+		}
+
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil || limit <= 0 {
+			limit = 10
+		}
+		offset, err := strconv.Atoi(r.URL.Query().Get("offset"))
+		if err != nil || offset < 0 {
+			offset = 0
+		}
+
+		if _, err := songsGetter.GetArtist(r.Context(), id); errors.Is(err, storage.ErrArtistNotFound) {
+			log.Error("artist not found", sl.Err(err))
+
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, resp.Error("artist not found"))
+
+			return
+		} else if err != nil {
+			log.Error("failed to get artist", sl.Err(err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		page := storage.Page{Limit: limit, Offset: offset, Sort: sort}
+		songs, err := songsGetter.GetSongs(r.Context(), &storage.SongFilter{ArtistID: id}, page)
+		if err != nil {
+			log.Error("failed to get songs", sl.Err(err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Debug("artist songs fetched", slog.Int("id", id), slog.Int("count", len(songs)))
+
+		render.JSON(w, r, formatSongs(songs))
+	}
+}
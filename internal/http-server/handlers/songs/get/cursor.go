@@ -0,0 +1,85 @@
+package get
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"song-library/internal/storage"
+)
+
+const (
+	headerNextCursor = "X-Next-Cursor"
+	headerPrevCursor = "X-Prev-Cursor"
+)
+
+// Cursors holds the encoded cursors of the neighbouring pages; empty
+// strings mean there is no such page.
+type Cursors struct {
+	Next string
+	Prev string
+}
+
+// parseCursor decodes the cursor query parameter and checks that it fits
+// the rest of the query.
+func parseCursor(token string, query url.Values) (*storage.Cursor, error) {
+	cursor, err := storage.DecodeCursor(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if fuzzy, _ := strconv.ParseBool(query.Get("fuzzy")); fuzzy {
+		return nil, fmt.Errorf("%w: cursor cannot be combined with fuzzy", storage.ErrInvalidCursor)
+	}
+	if query.Get("offset") != "" {
+		return nil, fmt.Errorf("%w: cursor cannot be combined with offset", storage.ErrInvalidCursor)
+	}
+	if sort := query.Get("sort"); sort != "" {
+		fields, err := storage.ParseSort(sort)
+		if err != nil || storage.FormatSort(fields) != storage.FormatSort(cursor.Sort) {
+			return nil, fmt.Errorf("%w: sort differs from the cursor's", storage.ErrInvalidCursor)
+		}
+	}
+
+	return cursor, nil
+}
+
+// paginate trims songs, fetched with page.Limit+1, to page.Limit and
+// builds the cursors of the neighbouring pages.
+func paginate(songs []*storage.Song, page storage.Page) ([]*storage.Song, Cursors) {
+	var (
+		cursors  Cursors
+		backward = page.Cursor != nil && page.Cursor.Backward
+		hasMore  = len(songs) > page.Limit
+	)
+
+	if hasMore {
+		if backward {
+			// Backward pages are read towards the start, so the extra song is the first one.
+			songs = songs[1:]
+		} else {
+			songs = songs[:page.Limit]
+		}
+	}
+	if len(songs) == 0 {
+		return songs, cursors
+	}
+
+	first, last := songs[0], songs[len(songs)-1]
+	if backward {
+		// We came from the page after this one, so it always exists.
+		cursors.Next = storage.CursorAt(last, page.Sort, false).Encode()
+		if hasMore {
+			cursors.Prev = storage.CursorAt(first, page.Sort, true).Encode()
+		}
+	} else {
+		if hasMore {
+			cursors.Next = storage.CursorAt(last, page.Sort, false).Encode()
+		}
+		if page.Cursor != nil || page.Offset > 0 {
+			cursors.Prev = storage.CursorAt(first, page.Sort, true).Encode()
+		}
+	}
+
+	return songs, cursors
+}
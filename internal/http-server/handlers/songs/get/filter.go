@@ -0,0 +1,170 @@
+package get
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"song-library/internal/storage"
+)
+
+const notNull = "not_null"
+
+// dateLayouts are the release date formats accepted in query parameters.
+var dateLayouts = []string{"02.01.2006", "02-01-2006", "2006-01-02"}
+
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// parseFilter builds a storage.SongFilter from the query parameters of GET /songs.
+func parseFilter(query url.Values) (*storage.SongFilter, error) {
+	filter := &storage.SongFilter{
+		Artist: query.Get("group"),
+		Title:  query.Get("song"),
+		Album:  query.Get("album"),
+	}
+
+	if fuzzy := query.Get("fuzzy"); fuzzy != "" {
+		var err error
+		if filter.Fuzzy, err = strconv.ParseBool(fuzzy); err != nil {
+			return nil, fmt.Errorf("%w: fuzzy must be true or false", ErrInvalidFilter)
+		}
+		if filter.Fuzzy && filter.Artist == "" && filter.Title == "" {
+			return nil, fmt.Errorf("%w: fuzzy needs group or song", ErrInvalidFilter)
+		}
+	}
+
+	if lyrics := query.Get("lyrics"); lyrics == notNull {
+		filter.HasLyrics = storage.PresencePresent
+	} else {
+		filter.Lyrics = lyrics
+	}
+
+	switch link := query.Get("link"); link {
+	case "":
+	case notNull:
+		filter.HasLink = storage.PresencePresent
+	default:
+		return nil, fmt.Errorf("%w: link must be %q", ErrInvalidFilter, notNull)
+	}
+
+	for key, presence := range map[string]*storage.Presence{
+		"has_lyrics": &filter.HasLyrics,
+		"has_link":   &filter.HasLink,
+	} {
+		p, err := parsePresence(query.Get(key))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrInvalidFilter, key, err)
+		}
+		if p != storage.PresenceAny {
+			*presence = p
+		}
+	}
+
+	for key, tags := range map[string]*storage.TagFilter{
+		"genre": &filter.Genres,
+		"tag":   &filter.Tags,
+	} {
+		var err error
+		if *tags, err = parseTagFilter(query, key); err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrInvalidFilter, key, err)
+		}
+	}
+
+	if releaseDate := query.Get("release_date"); releaseDate != "" {
+		from, to, err := parseDateRange(releaseDate)
+		if err != nil {
+			return nil, fmt.Errorf("%w: release_date: %w", ErrInvalidFilter, err)
+		}
+		filter.ReleasedFrom, filter.ReleasedTo = from, to
+	}
+
+	return filter, nil
+}
+
+// parseTagFilter reads the names of a genre or tag filter, given as
+// repeated or comma-separated values, and its <key>_match mode: "any"
+// (the default) or "all".
+func parseTagFilter(query url.Values, key string) (storage.TagFilter, error) {
+	var (
+		filter storage.TagFilter
+		names  []string
+	)
+	for _, value := range query[key] {
+		names = append(names, strings.Split(value, ",")...)
+	}
+
+	switch match := query.Get(key + "_match"); match {
+	case "", "any":
+	case "all":
+		filter.All = true
+	default:
+		return filter, fmt.Errorf("%s_match must be any or all, got %q", key, match)
+	}
+
+	if len(names) == 0 {
+		return filter, nil
+	}
+
+	var err error
+	if filter.Names, err = storage.NormalizeTags(names); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+func parsePresence(value string) (storage.Presence, error) {
+	switch value {
+	case "":
+		return storage.PresenceAny, nil
+	case "true":
+		return storage.PresencePresent, nil
+	case "false":
+		return storage.PresenceAbsent, nil
+	default:
+		return storage.PresenceAny, fmt.Errorf("want true or false, got %q", value)
+	}
+}
+
+// parseDateRange accepts a single date or a "from,to" range where
+// either side may be left empty.
+func parseDateRange(value string) (from, to time.Time, err error) {
+	fromStr, toStr, isRange := strings.Cut(value, ",")
+	if !isRange {
+		date, err := parseDate(value)
+		return date, date, err
+	}
+
+	if fromStr = strings.TrimSpace(fromStr); fromStr != "" {
+		if from, err = parseDate(fromStr); err != nil {
+			return from, to, err
+		}
+	}
+	if toStr = strings.TrimSpace(toStr); toStr != "" {
+		if to, err = parseDate(toStr); err != nil {
+			return from, to, err
+		}
+	}
+
+	if from.IsZero() && to.IsZero() {
+		return from, to, errors.New("empty range")
+	}
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return from, to, errors.New("start is after end")
+	}
+
+	return from, to, nil
+}
+
+func parseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date %q", value)
+}
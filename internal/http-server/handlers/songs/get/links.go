@@ -0,0 +1,73 @@
+package get
+
+import (
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"song-library/internal/storage"
+)
+
+// MediaTypeSongPage asks GET /songs for the enveloped response.
+const MediaTypeSongPage = "application/vnd.song-library.v2+json"
+
+// wantsEnvelope reports whether the client opted in to models.SongPage,
+// either with ?envelope=true or through the Accept header.
+func wantsEnvelope(r *http.Request) bool {
+	if envelope, err := strconv.ParseBool(r.URL.Query().Get("envelope")); err == nil {
+		return envelope
+	}
+
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err == nil && mediaType == MediaTypeSongPage {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pageLinks returns the URLs of the neighbouring pages. Requests made with
+// a cursor get cursor links, all others get offset links.
+func pageLinks(u *url.URL, page storage.Page, cursors Cursors) (next, prev string) {
+	if page.Cursor != nil {
+		if cursors.Next != "" {
+			next = linkWith(u, "cursor", cursors.Next)
+		}
+		if cursors.Prev != "" {
+			prev = linkWith(u, "cursor", cursors.Prev)
+		}
+		return next, prev
+	}
+
+	if cursors.Next != "" {
+		next = linkWith(u, "offset", strconv.Itoa(page.Offset+page.Limit))
+	}
+	if page.Offset > 0 {
+		prev = linkWith(u, "offset", strconv.Itoa(max(0, page.Offset-page.Limit)))
+	}
+
+	return next, prev
+}
+
+func linkWith(u *url.URL, key, value string) string {
+	query := u.Query()
+	query.Set(key, value)
+
+	return (&url.URL{Path: u.Path, RawQuery: query.Encode()}).String()
+}
+
+// linkHeader formats an RFC 8288 Link header value.
+func linkHeader(next, prev string) string {
+	var links []string
+	if next != "" {
+		links = append(links, "<"+next+`>; rel="next"`)
+	}
+	if prev != "" {
+		links = append(links, "<"+prev+`>; rel="prev"`)
+	}
+	return strings.Join(links, ", ")
+}
@@ -0,0 +1,74 @@
+package get
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"song-library/internal/lib/api/param"
+	"song-library/internal/lib/api/resp"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type SongByIDGetter interface {
+	GetSongByID(ctx context.Context, id int) (*storage.Song, error)
+}
+
+// @Summary Get a song by ID.
+// @Description Fetches a single song with all its details by its numeric ID.
+// @Tags songs
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Song ID" Example(1)
+// @Success 200 {object} models.Song "Song"
+// @Failure 400 {object} resp.Response "Bad Request - Invalid ID"
+// @Failure 404 {object} resp.Response "Not Found - Song not found"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /songs/{id} [get]
+func NewByID(log *slog.Logger, songGetter SongByIDGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.songs.get.NewByID"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := param.ID(r)
+		if err != nil {
+			log.Error("invalid song id", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("invalid song id"))
+
+			return
+		}
+
+		song, err := songGetter.GetSongByID(r.Context(), id)
+		if errors.Is(err, storage.ErrSongNotFound) {
+			log.Error("song not found", sl.Err(err))
+
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, resp.Error("song not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to get song", sl.Err(err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Debug("song found", slog.Int("id", id))
+
+		render.JSON(w, r, formatSong(song))
+	}
+}
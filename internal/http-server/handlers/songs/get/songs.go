@@ -15,47 +15,61 @@ import (
 )
 
 type SongsGetter interface {
-	GetSongs(ctx context.Context, filter *map[string]string, limit, offset int) ([]*storage.Song, error)
+	GetSongs(ctx context.Context, filter *storage.SongFilter, page storage.Page) ([]*storage.Song, error)
+	CountSongs(ctx context.Context, filter *storage.SongFilter) (int, error)
 }
 
 // @Summary Get a list of songs with optional filters and pagination.
 // @Description Fetches a list of songs with optional filters for artist, song title, release date, lyrics and link presence.
+// @Description The group filter matches featured artists, producers and composers as well as the primary artist.
+// @Description The response is a bare array unless envelope=true is set or the Accept header asks for application/vnd.song-library.v2+json;
+// @Description then it is a models.SongPage with the total count and links to the neighbouring pages.
 // @Tags songs
 // @Accept  json
 // @Produce  json
-// @Param group query string false "Artist Name" Example("The Beatles")
+// @Param group query string false "Artist Name; matches any credited artist, whatever the role" Example("The Beatles")
 // @Param song query string false "Song Title" Example("Hey Jude")
-// @Param release_date query string false "Release Date (single date or range: 'DD-MM-YYYY' or 'DD-MM-YYYY,DD-MM-YYYY')" Example("01-01-1970,31-12-1979")
+// @Param genre query string false "Genres, repeated or comma-separated; see genre_match" Example("rock,pop")
+// @Param genre_match query string false "Keep songs with any (default) or all of the genres" Enums(any, all)
+// @Param tag query string false "Tags, repeated or comma-separated; see tag_match" Example("live")
+// @Param tag_match query string false "Keep songs with any (default) or all of the tags" Enums(any, all)
+// @Param album query string false "Album title; keeps songs on a matching album" Example("Let It Be")
+// @Param fuzzy query bool false "Match group and song by trigram similarity, tolerating typos. Songs are ordered by score, best first, before any sort. Only offset pagination applies: no cursors are returned"
+// @Param release_date query string false "Release Date (single date or range, either side may be empty: 'DD.MM.YYYY', 'DD-MM-YYYY' or 'YYYY-MM-DD')" Example("01.01.1970,31.12.1979")
 // @Param lyrics query string false "Lyrics content or 'not_null' to filter songs with lyrics" Example("love")
 // @Param link query string false "Use 'not_null' to filter songs with links" Example("not_null")
+// @Param has_lyrics query bool false "Only songs with (true) or without (false) lyrics"
+// @Param has_link query bool false "Only songs with (true) or without (false) a link"
+// @Param sort query string false "Comma-separated sort keys: id, artist, title, release_date. Prefix with '-' for descending order; ties are broken by song ID" Example("-release_date,artist")
+// @Param cursor query string false "Opaque token from the X-Next-Cursor or X-Prev-Cursor header of a previous page. Keeps the sort of that page and cannot be combined with offset"
+// @Param envelope query bool false "Wrap the list in a models.SongPage envelope"
 // @Param limit query int false "Limit of songs to retrieve" Default(10)
 // @Param offset query int false "Offset for pagination" Default(0)
 // @Success 200 {array} models.Song "A list of songs"
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page, absent on the last page and with fuzzy"
+// @Header 200 {string} X-Prev-Cursor "Cursor of the previous page, absent on the first page and with fuzzy"
+// @Header 200 {string} Link "RFC 8288 links with rel=next and rel=prev"
 // @Failure 400 {object} resp.Response "Bad Request"
 // @Failure 500 {object} resp.Response "Internal Server Error"
 // @Router /songs [get]
 func New(log *slog.Logger, songsGetter SongsGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		filter := map[string]string{}
-
-		if group := r.URL.Query().Get("group"); group != "" {
-			filter["artist"] = group
-		}
-
-		if title := r.URL.Query().Get("song"); title != "" {
-			filter["title"] = title
-		}
+		filter, err := parseFilter(r.URL.Query())
+		if err != nil {
+			log.Info("invalid filter", sl.Err(err))
 
-		if releaseDate := r.URL.Query().Get("release_date"); releaseDate != "" {
-			filter["release_date"] = releaseDate
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(err.Error()))
+			return
 		}
 
-		if lyrics := r.URL.Query().Get("lyrics"); lyrics != "" {
-			filter["lyrics"] = lyrics
-		}
+		sort, err := storage.ParseSort(r.URL.Query().Get("sort"))
+		if err != nil {
+			log.Info("invalid sort", sl.Err(err))
 
-		if link := r.URL.Query().Get("link"); link != "" {
-			filter["link"] = link
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(err.Error()))
+			return
 		}
 
 		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
@@ -67,7 +81,22 @@ func New(log *slog.Logger, songsGetter SongsGetter) http.HandlerFunc {
 			offset = 0
 		}
 
-		songs, err := songsGetter.GetSongs(r.Context(), &filter, limit, offset)
+		page := storage.Page{Limit: limit, Offset: offset, Sort: sort}
+		if token := r.URL.Query().Get("cursor"); token != "" {
+			page.Cursor, err = parseCursor(token, r.URL.Query())
+			if err != nil {
+				log.Info("invalid cursor", sl.Err(err))
+
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error(err.Error()))
+				return
+			}
+			page.Sort = page.Cursor.Sort
+		}
+
+		// One extra song tells whether there is a page beyond this one.
+		page.Limit++
+		songs, err := songsGetter.GetSongs(r.Context(), filter, page)
 		if err != nil {
 			log.Error("failed to get song ", sl.Err(err))
 
@@ -75,29 +104,81 @@ func New(log *slog.Logger, songsGetter SongsGetter) http.HandlerFunc {
 			render.JSON(w, r, resp.Error("internal error"))
 			return
 		}
+		page.Limit--
+
+		songs, cursors := paginate(songs, page)
+		next, prev := pageLinks(r.URL, page, cursors)
+		if filter.Fuzzy {
+			// Fuzzy results are ordered by score, which a cursor cannot resume.
+			cursors = Cursors{}
+		}
 
-		response := formatSongs(songs)
+		if cursors.Next != "" {
+			w.Header().Set(headerNextCursor, cursors.Next)
+		}
+		if cursors.Prev != "" {
+			w.Header().Set(headerPrevCursor, cursors.Prev)
+		}
+		if link := linkHeader(next, prev); link != "" {
+			w.Header().Set("Link", link)
+		}
 
-		log.Debug("songs fetched", slog.Any("filter", filter), slog.Any("limit", limit), slog.Any("offset", offset))
+		log.Debug("songs fetched", slog.Any("filter", filter), slog.Any("sort", sort), slog.Any("limit", limit), slog.Any("offset", offset))
 
-		render.JSON(w, r, response)
+		if !wantsEnvelope(r) {
+			render.JSON(w, r, formatSongs(songs))
+			return
+		}
+
+		total, err := songsGetter.CountSongs(r.Context(), filter)
+		if err != nil {
+			log.Error("failed to count songs", sl.Err(err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+			return
+		}
+
+		render.JSON(w, r, models.SongPage{
+			Items:      formatSongs(songs),
+			Total:      total,
+			Limit:      page.Limit,
+			Offset:     page.Offset,
+			Next:       next,
+			Prev:       prev,
+			NextCursor: cursors.Next,
+			PrevCursor: cursors.Prev,
+		})
 	}
 }
 
 func formatSongs(songs []*storage.Song) []*models.Song {
 	formattedSongs := make([]*models.Song, len(songs))
 	for i, song := range songs {
-		releaseDate := ""
-		if !song.ReleaseDate.IsZero() {
-			releaseDate = song.ReleaseDate.Format("02.01.2006")
-		}
-		formattedSongs[i] = &models.Song{
-			Artist:      song.Artist,
-			Title:       song.Title,
-			ReleaseDate: releaseDate,
-			Text:        song.Lyrics,
-			Link:        song.Link,
-		}
+		formattedSongs[i] = formatSong(song)
 	}
 	return formattedSongs
 }
+
+func formatSong(song *storage.Song) *models.Song {
+	releaseDate := ""
+	if !song.ReleaseDate.IsZero() {
+		releaseDate = song.ReleaseDate.Format("02.01.2006")
+	}
+	var artists []models.Credit
+	for _, c := range song.Credits {
+		artists = append(artists, models.Credit{ID: c.ArtistID, Name: c.Artist, Role: string(c.Role)})
+	}
+	return &models.Song{
+		ID:          song.ID,
+		Artist:      song.Artist,
+		Title:       song.Title,
+		ReleaseDate: releaseDate,
+		Text:        song.Lyrics,
+		Link:        song.Link,
+		Genres:      song.Genres,
+		Tags:        song.Tags,
+		Artists:     artists,
+		Score:       song.Score,
+	}
+}
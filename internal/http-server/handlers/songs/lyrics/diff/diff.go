@@ -0,0 +1,196 @@
+package diff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"song-library/internal/lib/api/param"
+	"song-library/internal/lib/api/resp"
+	textDiff "song-library/internal/lib/diff"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+const (
+	formatJSON    = "json"
+	formatUnified = "unified"
+)
+
+type RevisionsGetter interface {
+	ListRevisions(ctx context.Context, songID, limit, offset int) ([]*storage.Revision, error)
+	GetRevision(ctx context.Context, songID, number int) (*storage.Revision, error)
+}
+
+// @Summary Diff song lyrics between revisions.
+// @Description Compares the lyrics of two revisions of a song line by line. Verses are separated by \n\n as in GET /songs/{id}/lyrics, and the blank line between them is kept aligned. To defaults to the latest revision and from to the one before it; the first revision is compared with empty lyrics. The unified format returns the diff as text/plain.
+// @Tags lyrics
+// @Accept  json
+// @Produce  json,plain
+// @Param id path int true "Song ID" Example(1)
+// @Param from query int false "Revision to compare from" Example(1)
+// @Param to query int false "Revision to compare to" Example(2)
+// @Param format query string false "Output format" Enums(json, unified) Default(json)
+// @Param context query int false "Kept lines around each change" Default(3)
+// @Success 200 {object} models.LyricsDiff "Lyrics diff"
+// @Failure 400 {object} resp.Response "Bad Request - Invalid ID, revision or format"
+// @Failure 404 {object} resp.Response "Not Found - Song or revision not found"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /songs/{id}/lyrics/diff [get]
+func New(log *slog.Logger, revisions RevisionsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.songs.lyrics.diff.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := param.ID(r)
+		if err != nil {
+			log.Error("invalid song id", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("invalid song id"))
+
+			return
+		}
+
+		query := r.URL.Query()
+
+		format := query.Get("format")
+		if format == "" {
+			format = formatJSON
+		}
+		if format != formatJSON && format != formatUnified {
+			log.Error("unknown diff format", slog.String("format", format))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("format must be json or unified"))
+
+			return
+		}
+
+		contextLines, err := strconv.Atoi(query.Get("context"))
+		if err != nil || contextLines < 0 {
+			contextLines = 3
+		}
+
+		from, fromErr := revisionNumber(query.Get("from"))
+		to, toErr := revisionNumber(query.Get("to"))
+		if err := errors.Join(fromErr, toErr); err != nil {
+			log.Error("invalid revision number", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("invalid revision number"))
+
+			return
+		}
+
+		if to == 0 {
+			latest, err := revisions.ListRevisions(r.Context(), id, 1, 0)
+			if errors.Is(err, storage.ErrSongNotFound) {
+				log.Error("song not found", sl.Err(err))
+
+				w.WriteHeader(http.StatusNotFound)
+				render.JSON(w, r, resp.Error("song not found"))
+
+				return
+			}
+			if err != nil {
+				log.Error("failed to list revisions", sl.Err(err))
+
+				w.WriteHeader(http.StatusInternalServerError)
+				render.JSON(w, r, resp.Error("internal error"))
+
+				return
+			}
+			to = latest[0].Number
+		}
+		if from == 0 {
+			from = to - 1
+		}
+
+		var lyrics [2]string
+		for i, number := range []int{from, to} {
+			if number == 0 {
+				continue
+			}
+
+			rev, err := revisions.GetRevision(r.Context(), id, number)
+			if errors.Is(err, storage.ErrRevisionNotFound) {
+				log.Error("revision not found", sl.Err(err), slog.Int("rev", number))
+
+				w.WriteHeader(http.StatusNotFound)
+				render.JSON(w, r, resp.Error("revision not found"))
+
+				return
+			}
+			if err != nil {
+				log.Error("failed to get revision", sl.Err(err))
+
+				w.WriteHeader(http.StatusInternalServerError)
+				render.JSON(w, r, resp.Error("internal error"))
+
+				return
+			}
+			lyrics[i] = rev.Song.Lyrics
+		}
+
+		hunks := textDiff.Lyrics(lyrics[0], lyrics[1], contextLines)
+
+		log.Debug("lyrics diffed", slog.Int("id", id), slog.Int("from", from), slog.Int("to", to))
+
+		if format == formatUnified {
+			render.PlainText(w, r, textDiff.Unified(fmt.Sprintf("rev %d", from), fmt.Sprintf("rev %d", to), hunks))
+			return
+		}
+
+		render.JSON(w, r, formatDiff(from, to, hunks))
+	}
+}
+
+// revisionNumber parses an optional revision number; 0 means it is absent.
+func revisionNumber(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid revision number %q", s)
+	}
+	return n, nil
+}
+
+func formatDiff(from, to int, hunks []textDiff.Hunk) models.LyricsDiff {
+	res := models.LyricsDiff{From: from, To: to, Hunks: make([]models.DiffHunk, 0, len(hunks))}
+	for _, h := range hunks {
+		hunk := models.DiffHunk{
+			OldStart: h.OldStart,
+			OldLines: h.OldLines,
+			NewStart: h.NewStart,
+			NewLines: h.NewLines,
+			Verse:    h.Verse,
+			Lines:    make([]models.DiffLine, 0, len(h.Lines)),
+		}
+		for _, l := range h.Lines {
+			hunk.Lines = append(hunk.Lines, models.DiffLine{
+				Op:    string(l.Op),
+				Text:  l.Text,
+				Verse: l.Verse,
+				Old:   l.Old,
+				New:   l.New,
+			})
+		}
+		res.Hunks = append(res.Hunks, hunk)
+	}
+	return res
+}
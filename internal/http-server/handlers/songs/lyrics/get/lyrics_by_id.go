@@ -0,0 +1,87 @@
+package get
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"song-library/internal/lib/api/param"
+	"song-library/internal/lib/api/resp"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type LyricsByIDGetter interface {
+	GetSongLyricsByID(ctx context.Context, id, limit, offset int) (string, error)
+}
+
+// @Summary Get song lyrics by song ID with optional pagination.
+// @Description Fetches lyrics of a song by its numeric ID, paginated by verses.
+// @Tags lyrics
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Song ID" Example(1)
+// @Param limit query int false "Limit the number of verses to retrieve" Default(10)
+// @Param offset query int false "Offset for pagination" Default(0)
+// @Success 200 {object} models.Lyrics "Song lyrics successfully retrieved"
+// @Failure 400 {object} resp.Response "Bad Request - Invalid ID"
+// @Failure 404 {object} resp.Response "Not Found - Song not found"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /songs/{id}/lyrics [get]
+func NewByID(log *slog.Logger, lyricsGetter LyricsByIDGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.songs.lyrics.get.NewByID"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := param.ID(r)
+		if err != nil {
+			log.Error("invalid song id", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("invalid song id"))
+
+			return
+		}
+
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil || limit <= 0 {
+			limit = 10
+		}
+		offset, err := strconv.Atoi(r.URL.Query().Get("offset"))
+		if err != nil || offset < 0 {
+			offset = 0
+		}
+
+		lyrics, err := lyricsGetter.GetSongLyricsByID(r.Context(), id, limit, offset)
+		if errors.Is(err, storage.ErrSongNotFound) {
+			log.Error("song not found", sl.Err(err))
+
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, resp.Error("song not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to fetch lyrics", sl.Err(err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Debug("song lyrics fetched", slog.Int("id", id))
+
+		render.JSON(w, r, models.Lyrics{Text: lyrics})
+	}
+}
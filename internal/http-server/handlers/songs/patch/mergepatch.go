@@ -0,0 +1,133 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"song-library/internal/models"
+	"song-library/internal/storage"
+)
+
+const ContentType = "application/merge-patch+json"
+
+var (
+	ErrEmptyPatch   = errors.New("empty patch")
+	ErrInvalidPatch = errors.New("invalid patch")
+)
+
+// parseMergePatch reads an RFC 7396 JSON Merge Patch for a song.
+// Absent keys are left unchanged, null clears release_date, text and link.
+// genres and tags replace the labels of the song, artists the credits
+// other than the primary artist; null clears them.
+// group and song rename the song and cannot be null.
+func parseMergePatch(body io.Reader) (*storage.SongUpdate, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, ErrEmptyPatch
+	}
+
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil || doc == nil {
+		return nil, fmt.Errorf("%w: body must be a JSON object", ErrInvalidPatch)
+	}
+	if len(doc) == 0 {
+		return nil, ErrEmptyPatch
+	}
+
+	var upd storage.SongUpdate
+	for key, raw := range doc {
+		switch key {
+		case "group":
+			value, err := requiredString(key, raw)
+			if err != nil {
+				return nil, err
+			}
+			upd.Artist = value
+		case "song":
+			value, err := requiredString(key, raw)
+			if err != nil {
+				return nil, err
+			}
+			upd.Title = value
+		case "text":
+			value, err := nullableString(key, raw)
+			if err != nil {
+				return nil, err
+			}
+			upd.Lyrics = &value
+		case "link":
+			value, err := nullableString(key, raw)
+			if err != nil {
+				return nil, err
+			}
+			upd.Link = &value
+		case "release_date":
+			value, err := nullableString(key, raw)
+			if err != nil {
+				return nil, err
+			}
+			var releaseDate time.Time
+			if value != "" {
+				releaseDate, err = time.Parse("02.01.2006", value)
+				if err != nil {
+					return nil, fmt.Errorf("%w: release_date must be DD.MM.YYYY", ErrInvalidPatch)
+				}
+			}
+			upd.ReleaseDate = &releaseDate
+		case "genres", "tags":
+			var names []string
+			if err := json.Unmarshal(raw, &names); err != nil {
+				return nil, fmt.Errorf("%w: %s must be a list of strings or null", ErrInvalidPatch, key)
+			}
+			if names == nil {
+				names = []string{}
+			}
+			if key == "genres" {
+				upd.Genres = &names
+			} else {
+				upd.Tags = &names
+			}
+		case "artists":
+			var artists []models.Credit
+			if err := json.Unmarshal(raw, &artists); err != nil {
+				return nil, fmt.Errorf("%w: artists must be a list of {name, role} objects or null", ErrInvalidPatch)
+			}
+			credits := make([]storage.Credit, len(artists))
+			for i, c := range artists {
+				credits[i] = storage.Credit{Artist: c.Name, Role: storage.ArtistRole(c.Role)}
+			}
+			upd.Credits = &credits
+		default:
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidPatch, key)
+		}
+	}
+
+	return &upd, nil
+}
+
+func requiredString(key string, raw json.RawMessage) (string, error) {
+	var value *string
+	if err := json.Unmarshal(raw, &value); err != nil || value == nil || *value == "" {
+		return "", fmt.Errorf("%w: %s must be a non-empty string", ErrInvalidPatch, key)
+	}
+	return *value, nil
+}
+
+// nullableString returns "" for null.
+func nullableString(key string, raw json.RawMessage) (string, error) {
+	var value *string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", fmt.Errorf("%w: %s must be a string or null", ErrInvalidPatch, key)
+	}
+	if value == nil {
+		return "", nil
+	}
+	return *value, nil
+}
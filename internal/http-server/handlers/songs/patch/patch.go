@@ -0,0 +1,184 @@
+package patch
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"mime"
+	"net/http"
+
+	"song-library/internal/lib/api/param"
+	"song-library/internal/lib/api/resp"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type SongUpdater interface {
+	UpdateSong(ctx context.Context, artist, title string, upd *storage.SongUpdate) error
+}
+
+type SongByIDUpdater interface {
+	UpdateSongByID(ctx context.Context, id int, upd *storage.SongUpdate) error
+}
+
+// @Summary Partially update a song by artist and title.
+// @Description Applies a JSON Merge Patch (RFC 7396) to the song identified by exact artist and title. Absent keys are left unchanged; null clears release_date, text or link; genres, tags and artists replace those of the song, null clears them; group and song move or rename the song.
+// @Tags songs
+// @Accept  application/merge-patch+json
+// @Produce  json
+// @Param group query string true "Artist Name" Example("The Beatles")
+// @Param song query string true "Song Title" Example("Hey Jude")
+// @Param request body models.SongChanges true "Merge patch; use null to clear a field"
+// @Success 200 {object} resp.Response "Song successfully updated"
+// @Failure 400 {object} resp.Response "Bad Request"
+// @Failure 404 {object} resp.Response "Not Found - Song not found"
+// @Failure 409 {object} resp.Response "Conflict - Target artist already has a song with this title"
+// @Failure 415 {object} resp.Response "Unsupported Media Type"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /songs [patch]
+func New(log *slog.Logger, songUpdater SongUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.songs.patch.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		artist := r.URL.Query().Get("group")
+		title := r.URL.Query().Get("song")
+
+		if artist == "" || title == "" {
+			log.Error("missing required parameters")
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("missing required parameters: group and song"))
+
+			return
+		}
+
+		apply(w, r, log, func(upd *storage.SongUpdate) error {
+			return songUpdater.UpdateSong(r.Context(), artist, title, upd)
+		})
+	}
+}
+
+// @Summary Partially update a song by ID.
+// @Description Applies a JSON Merge Patch (RFC 7396) to the song with the given ID. Absent keys are left unchanged; null clears release_date, text or link; genres, tags and artists replace those of the song, null clears them; group and song move or rename the song.
+// @Tags songs
+// @Accept  application/merge-patch+json
+// @Produce  json
+// @Param id path int true "Song ID" Example(1)
+// @Param request body models.SongChanges true "Merge patch; use null to clear a field"
+// @Success 200 {object} resp.Response "Song successfully updated"
+// @Failure 400 {object} resp.Response "Bad Request"
+// @Failure 404 {object} resp.Response "Not Found - Song not found"
+// @Failure 409 {object} resp.Response "Conflict - Target artist already has a song with this title"
+// @Failure 415 {object} resp.Response "Unsupported Media Type"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /songs/{id} [patch]
+func NewByID(log *slog.Logger, songUpdater SongByIDUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.songs.patch.NewByID"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := param.ID(r)
+		if err != nil {
+			log.Error("invalid song id", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("invalid song id"))
+
+			return
+		}
+
+		apply(w, r, log, func(upd *storage.SongUpdate) error {
+			return songUpdater.UpdateSongByID(r.Context(), id, upd)
+		})
+	}
+}
+
+// apply parses the merge patch from the request and passes it to update.
+func apply(w http.ResponseWriter, r *http.Request, log *slog.Logger, update func(*storage.SongUpdate) error) {
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != ContentType && mediaType != "application/json" {
+		log.Error("unsupported content type", slog.String("content_type", r.Header.Get("Content-Type")))
+
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		render.JSON(w, r, resp.Error("content type must be "+ContentType))
+
+		return
+	}
+
+	upd, err := parseMergePatch(r.Body)
+	if errors.Is(err, ErrEmptyPatch) {
+		log.Error("nothing to change")
+
+		w.WriteHeader(http.StatusBadRequest)
+		render.JSON(w, r, resp.Error("nothing to change"))
+
+		return
+	}
+	if err != nil {
+		log.Error("invalid merge patch", sl.Err(err))
+
+		w.WriteHeader(http.StatusBadRequest)
+		render.JSON(w, r, resp.Error(err.Error()))
+
+		return
+	}
+
+	log.Debug("merge patch decoded", slog.Any("patch", upd))
+
+	err = update(upd)
+	if errors.Is(err, storage.ErrSongNotFound) {
+		log.Error("song not found", sl.Err(err))
+
+		w.WriteHeader(http.StatusNotFound)
+		render.JSON(w, r, resp.Error("song not found"))
+
+		return
+	}
+	if errors.Is(err, storage.ErrSongExists) {
+		log.Error("song already exists", sl.Err(err))
+
+		w.WriteHeader(http.StatusConflict)
+		render.JSON(w, r, resp.Error("song already exists"))
+
+		return
+	}
+	if errors.Is(err, storage.ErrInvalidTag) {
+		log.Error("invalid tag", sl.Err(err))
+
+		w.WriteHeader(http.StatusBadRequest)
+		render.JSON(w, r, resp.Error(resp.InvalidTag))
+
+		return
+	}
+	if errors.Is(err, storage.ErrInvalidCredit) {
+		log.Error("invalid credit", sl.Err(err))
+
+		w.WriteHeader(http.StatusBadRequest)
+		render.JSON(w, r, resp.Error(resp.InvalidCredit))
+
+		return
+	}
+	if err != nil {
+		log.Error("failed to update song", sl.Err(err))
+
+		w.WriteHeader(http.StatusInternalServerError)
+		render.JSON(w, r, resp.Error("internal error"))
+
+		return
+	}
+
+	log.Debug("song patched")
+
+	render.JSON(w, r, resp.OK())
+}
@@ -0,0 +1,125 @@
+package revisions
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"song-library/internal/lib/api/param"
+	"song-library/internal/lib/api/resp"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type RevisionGetter interface {
+	GetRevision(ctx context.Context, songID, number int) (*storage.Revision, error)
+}
+
+// @Summary Get a revision of a song.
+// @Description Fetches a revision with the full snapshot of the song it recorded. The release date in the snapshot is the song's own, without the album fallback.
+// @Tags songs
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Song ID" Example(1)
+// @Param rev path int true "Revision number" Example(2)
+// @Success 200 {object} models.RevisionDetail "Revision"
+// @Failure 400 {object} resp.Response "Bad Request - Invalid ID or revision"
+// @Failure 404 {object} resp.Response "Not Found - Revision not found"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /songs/{id}/revisions/{rev} [get]
+func NewByNumber(log *slog.Logger, getter RevisionGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.songs.revisions.NewByNumber"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, number, ok := parseParams(w, r, log)
+		if !ok {
+			return
+		}
+
+		rev, err := getter.GetRevision(r.Context(), id, number)
+		if errors.Is(err, storage.ErrRevisionNotFound) {
+			log.Error("revision not found", sl.Err(err))
+
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, resp.Error("revision not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to get revision", sl.Err(err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Debug("revision found", slog.Int("id", id), slog.Int("rev", number))
+
+		render.JSON(w, r, models.RevisionDetail{
+			Revision: formatRevision(rev),
+			Song:     formatSnapshot(&rev.Song),
+		})
+	}
+}
+
+// parseParams reads the {id} and {rev} URL parameters, answering 400 if
+// either is invalid.
+func parseParams(w http.ResponseWriter, r *http.Request, log *slog.Logger) (int, int, bool) {
+	id, err := param.ID(r)
+	if err != nil {
+		log.Error("invalid song id", sl.Err(err))
+
+		w.WriteHeader(http.StatusBadRequest)
+		render.JSON(w, r, resp.Error("invalid song id"))
+
+		return 0, 0, false
+	}
+
+	number, err := param.PositiveInt(r, "rev")
+	if err != nil {
+		log.Error("invalid revision number", sl.Err(err))
+
+		w.WriteHeader(http.StatusBadRequest)
+		render.JSON(w, r, resp.Error("invalid revision number"))
+
+		return 0, 0, false
+	}
+
+	return id, number, true
+}
+
+// formatSnapshot renders a revision snapshot like a song, the primary
+// artist first among the artists.
+func formatSnapshot(song *storage.Song) *models.Song {
+	releaseDate := ""
+	if !song.ReleaseDate.IsZero() {
+		releaseDate = song.ReleaseDate.Format("02.01.2006")
+	}
+
+	artists := []models.Credit{{Name: song.Artist, Role: string(storage.RolePrimary)}}
+	for _, c := range song.Credits {
+		artists = append(artists, models.Credit{Name: c.Artist, Role: string(c.Role)})
+	}
+
+	return &models.Song{
+		Artist:      song.Artist,
+		Title:       song.Title,
+		ReleaseDate: releaseDate,
+		Text:        song.Lyrics,
+		Link:        song.Link,
+		Genres:      song.Genres,
+		Tags:        song.Tags,
+		Artists:     artists,
+	}
+}
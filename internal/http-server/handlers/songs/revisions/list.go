@@ -0,0 +1,103 @@
+package revisions
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"song-library/internal/lib/api/param"
+	"song-library/internal/lib/api/resp"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type RevisionsLister interface {
+	ListRevisions(ctx context.Context, songID, limit, offset int) ([]*storage.Revision, error)
+}
+
+// @Summary List the revisions of a song.
+// @Description Lists the recorded adds, updates, deletes and restores of a song, newest first. Deleted songs keep their revisions.
+// @Tags songs
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Song ID" Example(1)
+// @Param limit query int false "Limit of revisions to retrieve" Default(20)
+// @Param offset query int false "Offset for pagination" Default(0)
+// @Success 200 {array} models.Revision "Revisions"
+// @Failure 400 {object} resp.Response "Bad Request - Invalid ID"
+// @Failure 404 {object} resp.Response "Not Found - Song never existed"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /songs/{id}/revisions [get]
+func New(log *slog.Logger, lister RevisionsLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.songs.revisions.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := param.ID(r)
+		if err != nil {
+			log.Error("invalid song id", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("invalid song id"))
+
+			return
+		}
+
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil || limit <= 0 {
+			limit = 20
+		}
+		offset, err := strconv.Atoi(r.URL.Query().Get("offset"))
+		if err != nil || offset < 0 {
+			offset = 0
+		}
+
+		revisions, err := lister.ListRevisions(r.Context(), id, limit, offset)
+		if errors.Is(err, storage.ErrSongNotFound) {
+			log.Error("song not found", sl.Err(err))
+
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, resp.Error("song not found"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to list revisions", sl.Err(err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		response := make([]models.Revision, len(revisions))
+		for i, rev := range revisions {
+			response[i] = formatRevision(rev)
+		}
+
+		log.Debug("revisions fetched", slog.Int("id", id), slog.Int("limit", limit), slog.Int("offset", offset))
+
+		render.JSON(w, r, response)
+	}
+}
+
+func formatRevision(rev *storage.Revision) models.Revision {
+	return models.Revision{
+		Number:    rev.Number,
+		Action:    string(rev.Action),
+		Author:    rev.Author,
+		CreatedAt: rev.CreatedAt,
+		Artist:    rev.Song.Artist,
+		Title:     rev.Song.Title,
+	}
+}
@@ -0,0 +1,79 @@
+package revisions
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"song-library/internal/lib/api/resp"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type RevisionRestorer interface {
+	RestoreRevision(ctx context.Context, songID, number int) error
+}
+
+// @Summary Restore a revision of a song.
+// @Description Brings the song back to the state recorded by a revision and records the restore as a new revision. A song in the trash is taken out of it; a purged one is recreated under its old ID, without its album tracks. The X-User header names the author.
+// @Tags songs
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Song ID" Example(1)
+// @Param rev path int true "Revision number" Example(2)
+// @Param X-User header string false "Author recorded in the revision"
+// @Success 200 {object} resp.Response "Revision restored"
+// @Failure 400 {object} resp.Response "Bad Request - Invalid ID or revision"
+// @Failure 404 {object} resp.Response "Not Found - Revision not found"
+// @Failure 409 {object} resp.Response "Conflict - The artist already has another song with the restored title"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /songs/{id}/revisions/{rev}/restore [post]
+func NewRestore(log *slog.Logger, restorer RevisionRestorer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.songs.revisions.NewRestore"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, number, ok := parseParams(w, r, log)
+		if !ok {
+			return
+		}
+
+		err := restorer.RestoreRevision(r.Context(), id, number)
+		if errors.Is(err, storage.ErrRevisionNotFound) {
+			log.Error("revision not found", sl.Err(err))
+
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, resp.Error("revision not found"))
+
+			return
+		}
+		if errors.Is(err, storage.ErrSongExists) {
+			log.Error("song already exists", sl.Err(err))
+
+			w.WriteHeader(http.StatusConflict)
+			render.JSON(w, r, resp.Error("song already exists"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to restore revision", sl.Err(err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Debug("revision restored", slog.Int("id", id), slog.Int("rev", number))
+
+		render.JSON(w, r, resp.OK())
+	}
+}
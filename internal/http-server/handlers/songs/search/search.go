@@ -0,0 +1,109 @@
+package search
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"song-library/internal/lib/api/resp"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type LyricsSearcher interface {
+	SearchLyrics(ctx context.Context, query storage.LyricsQuery, limit, offset int) ([]*storage.SearchHit, error)
+}
+
+// @Summary Full-text search in song lyrics.
+// @Description Searches lyrics and returns the best matches first, each with a snippet where matched words are wrapped in <mark></mark>. The rest of the snippet is HTML-escaped, so it is safe to render as HTML.
+// @Description The query uses web search syntax: words must all match, "quoted text" is a phrase, "or" separates alternatives and "-word" excludes a word.
+// @Tags songs
+// @Accept  json
+// @Produce  json
+// @Param q query string true "Search query" Example("\"soul alight\" or paranoia")
+// @Param config query string false "Text search configuration: simple (words as written), english or russian (stemmed)" Enums(simple, english, russian) Default(simple)
+// @Param limit query int false "Limit of songs to retrieve" Default(10)
+// @Param offset query int false "Offset for pagination" Default(0)
+// @Success 200 {array} models.SearchHit "Matching songs, best first"
+// @Failure 400 {object} resp.Response "Bad Request"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /songs/search [get]
+func New(log *slog.Logger, searcher LyricsSearcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.songs.search.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		text := strings.TrimSpace(r.URL.Query().Get("q"))
+		if text == "" {
+			log.Info("empty search query")
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("q is required"))
+
+			return
+		}
+
+		config, err := storage.ParseSearchConfig(r.URL.Query().Get("config"))
+		if err != nil {
+			log.Info("invalid search config", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(err.Error()))
+
+			return
+		}
+
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil || limit <= 0 {
+			limit = 10
+		}
+		offset, err := strconv.Atoi(r.URL.Query().Get("offset"))
+		if err != nil || offset < 0 {
+			offset = 0
+		}
+
+		hits, err := searcher.SearchLyrics(r.Context(), storage.LyricsQuery{Text: text, Config: config}, limit, offset)
+		if err != nil {
+			log.Error("failed to search lyrics", sl.Err(err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Debug("lyrics searched", slog.String("q", text), slog.Int("hits", len(hits)))
+
+		render.JSON(w, r, formatHits(hits))
+	}
+}
+
+func formatHits(hits []*storage.SearchHit) []*models.SearchHit {
+	formatted := make([]*models.SearchHit, len(hits))
+	for i, hit := range hits {
+		releaseDate := ""
+		if !hit.Song.ReleaseDate.IsZero() {
+			releaseDate = hit.Song.ReleaseDate.Format("02.01.2006")
+		}
+		formatted[i] = &models.SearchHit{
+			ID:          hit.Song.ID,
+			Artist:      hit.Song.Artist,
+			Title:       hit.Song.Title,
+			ReleaseDate: releaseDate,
+			Link:        hit.Song.Link,
+			Rank:        hit.Rank,
+			Headline:    hit.Headline,
+		}
+	}
+	return formatted
+}
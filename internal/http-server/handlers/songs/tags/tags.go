@@ -0,0 +1,176 @@
+package tags
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"song-library/internal/lib/api/param"
+	"song-library/internal/lib/api/resp"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type SongTagger interface {
+	TagSong(ctx context.Context, songID int, kind storage.TagKind, names []string) error
+}
+
+type SongUntagger interface {
+	UntagSong(ctx context.Context, songID int, kind storage.TagKind, names []string) error
+}
+
+// @Summary Attach genres or tags to a song.
+// @Description Adds the names to the genres or tags of the song. Names are stored in lower case with whitespace collapsed; ones the song already has are ignored.
+// @Tags songs
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Song ID" Example(1)
+// @Param request body models.TagsRequest true "Names to attach"
+// @Success 200 {object} resp.Response "Attached"
+// @Failure 400 {object} resp.Response "Bad Request"
+// @Failure 404 {object} resp.Response "Not Found - Song not found"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /songs/{id}/tags [post]
+// @Router /songs/{id}/genres [post]
+func NewAttach(log *slog.Logger, tagger SongTagger, kind storage.TagKind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.songs.tags.NewAttach"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			slog.String("kind", string(kind)),
+		)
+
+		id, err := param.ID(r)
+		if err != nil {
+			log.Error("invalid song id", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("invalid song id"))
+
+			return
+		}
+
+		var req models.TagsRequest
+
+		err = render.DecodeJSON(r.Body, &req)
+		if errors.Is(err, io.EOF) {
+			log.Error("request body is empty")
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("empty request"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("invalid request body"))
+
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		respond(w, r, log, tagger.TagSong(r.Context(), id, kind, req.Names))
+	}
+}
+
+// @Summary Detach a genre or tag from a song.
+// @Description Removes the name from the genres or tags of the song. Removing a name the song does not have succeeds.
+// @Tags songs
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Song ID" Example(1)
+// @Param name path string true "Genre or tag name" Example("live favourite")
+// @Success 200 {object} resp.Response "Detached"
+// @Failure 400 {object} resp.Response "Bad Request"
+// @Failure 404 {object} resp.Response "Not Found - Song not found"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /songs/{id}/tags/{name} [delete]
+// @Router /songs/{id}/genres/{name} [delete]
+func NewDetach(log *slog.Logger, untagger SongUntagger, kind storage.TagKind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.songs.tags.NewDetach"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			slog.String("kind", string(kind)),
+		)
+
+		id, err := param.ID(r)
+		if err != nil {
+			log.Error("invalid song id", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("invalid song id"))
+
+			return
+		}
+
+		name, err := url.PathUnescape(chi.URLParam(r, "name"))
+		if err != nil {
+			log.Error("invalid name", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("invalid name"))
+
+			return
+		}
+
+		respond(w, r, log, untagger.UntagSong(r.Context(), id, kind, []string{name}))
+	}
+}
+
+// respond reports the result of a tag change.
+func respond(w http.ResponseWriter, r *http.Request, log *slog.Logger, err error) {
+	if errors.Is(err, storage.ErrSongNotFound) {
+		log.Error("song not found", sl.Err(err))
+
+		w.WriteHeader(http.StatusNotFound)
+		render.JSON(w, r, resp.Error("song not found"))
+
+		return
+	}
+	if errors.Is(err, storage.ErrInvalidTag) {
+		log.Error("invalid tag", sl.Err(err))
+
+		w.WriteHeader(http.StatusBadRequest)
+		render.JSON(w, r, resp.Error(resp.InvalidTag))
+
+		return
+	}
+	if err != nil {
+		log.Error("failed to change tags", sl.Err(err))
+
+		w.WriteHeader(http.StatusInternalServerError)
+		render.JSON(w, r, resp.Error("internal error"))
+
+		return
+	}
+
+	log.Debug("tags changed")
+
+	render.JSON(w, r, resp.OK())
+}
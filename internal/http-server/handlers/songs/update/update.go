@@ -19,18 +19,19 @@ import (
 )
 
 type SongUpdater interface {
-	UpdateSong(ctx context.Context, song *storage.Song) error
+	UpdateSong(ctx context.Context, artist, title string, upd *storage.SongUpdate) error
 }
 
 // @Summary Update song details by artist and title.
-// @Description Updates the details of a song by artist and title. Only the fields that are provided in the request body will be updated. Fields like lyrics, release date, and link are optional.
+// @Description Updates the details of a song by artist and title. Only the fields that are provided in the request body will be updated. Fields like lyrics, release date, and link are optional. Set new_group and/or new_song to move the song to another artist (created if missing) or rename it. Artists, if present, replace the featured, producer and composer credits.
 // @Tags songs
 // @Accept  json
 // @Produce  json
-// @Param request body models.Song true "New song info "
+// @Param request body models.SongUpdate true "New song info "
 // @Success 200 {object} resp.Response "Song successfully updated"
 // @Failure 400 {object} resp.Response "Bad Request"
 // @Failure 404 {object} resp.Response "Not Found - Song not found"
+// @Failure 409 {object} resp.Response "Conflict - Target artist already has a song with this title"
 // @Failure 500 {object} resp.Response "Internal Server Error"
 // @Router /songs [put]
 func New(log *slog.Logger, songUpdater SongUpdater) http.HandlerFunc {
@@ -42,7 +43,7 @@ func New(log *slog.Logger, songUpdater SongUpdater) http.HandlerFunc {
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
 
-		var req models.Song
+		var req models.SongUpdate
 
 		err := render.DecodeJSON(r.Body, &req)
 		if errors.Is(err, io.EOF) {
@@ -74,18 +75,32 @@ func New(log *slog.Logger, songUpdater SongUpdater) http.HandlerFunc {
 			return
 		}
 
-		if req.Text == "" && req.Link == "" && req.ReleaseDate == "" {
+		if req.NewArtist == "" && req.NewTitle == "" && req.Text == "" && req.Link == "" && req.ReleaseDate == "" &&
+			req.Genres == nil && req.Tags == nil && req.Artists == nil {
 			log.Error("nothing to change")
 			w.WriteHeader(http.StatusBadRequest)
 			render.JSON(w, r, resp.Error("nothing to change"))
 			return
 		}
 
-		song := storage.Song{
-			Artist: req.Artist,
-			Title:  req.Title,
-			Lyrics: req.Text,
-			Link:   req.Link,
+		upd := storage.SongUpdate{
+			Artist: req.NewArtist,
+			Title:  req.NewTitle,
+		}
+		if req.Text != "" {
+			upd.Lyrics = &req.Text
+		}
+		if req.Link != "" {
+			upd.Link = &req.Link
+		}
+		if req.Genres != nil {
+			upd.Genres = &req.Genres
+		}
+		if req.Tags != nil {
+			upd.Tags = &req.Tags
+		}
+		if req.Artists != nil {
+			upd.Credits = credits(req.Artists)
 		}
 
 		if req.ReleaseDate != "" {
@@ -93,15 +108,15 @@ func New(log *slog.Logger, songUpdater SongUpdater) http.HandlerFunc {
 			if err != nil {
 				log.Error("failed to parse song release date", sl.Err(err))
 
-				w.WriteHeader(http.StatusInternalServerError)
-				render.JSON(w, r, resp.Error("internal error"))
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("bad release date"))
 
 				return
 			}
-			song.ReleaseDate = releaseDate
+			upd.ReleaseDate = &releaseDate
 		}
 
-		err = songUpdater.UpdateSong(r.Context(), &song)
+		err = songUpdater.UpdateSong(r.Context(), req.Artist, req.Title, &upd)
 		if errors.Is(err, storage.ErrSongNotFound) {
 			log.Error("song not found", sl.Err(err))
 			w.WriteHeader(http.StatusNotFound)
@@ -109,6 +124,30 @@ func New(log *slog.Logger, songUpdater SongUpdater) http.HandlerFunc {
 
 			return
 		}
+		if errors.Is(err, storage.ErrSongExists) {
+			log.Error("song already exists", sl.Err(err))
+
+			w.WriteHeader(http.StatusConflict)
+			render.JSON(w, r, resp.Error("song already exists"))
+
+			return
+		}
+		if errors.Is(err, storage.ErrInvalidTag) {
+			log.Error("invalid tag", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.InvalidTag))
+
+			return
+		}
+		if errors.Is(err, storage.ErrInvalidCredit) {
+			log.Error("invalid credit", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.InvalidCredit))
+
+			return
+		}
 		if err != nil {
 			log.Error("failed to update song", sl.Err(err))
 
@@ -126,3 +165,13 @@ func New(log *slog.Logger, songUpdater SongUpdater) http.HandlerFunc {
 		render.JSON(w, r, resp.OK())
 	}
 }
+
+// credits converts the artists of a request into the credits of a
+// storage.SongUpdate. An empty list clears the credits.
+func credits(artists []models.Credit) *[]storage.Credit {
+	res := make([]storage.Credit, len(artists))
+	for i, c := range artists {
+		res[i] = storage.Credit{Artist: c.Name, Role: storage.ArtistRole(c.Role)}
+	}
+	return &res
+}
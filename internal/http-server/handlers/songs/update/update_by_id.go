@@ -0,0 +1,168 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"song-library/internal/lib/api/param"
+	"song-library/internal/lib/api/resp"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type SongByIDUpdater interface {
+	UpdateSongByID(ctx context.Context, id int, upd *storage.SongUpdate) error
+}
+
+// @Summary Update song details by ID.
+// @Description Updates the details of the song with the given numeric ID. Only the fields that are provided in the request body will be updated. Group and song move the song to another artist (created if missing) or rename it. Artists, if present, replace the featured, producer and composer credits.
+// @Tags songs
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Song ID" Example(1)
+// @Param request body models.SongChanges true "New song info"
+// @Success 200 {object} resp.Response "Song successfully updated"
+// @Failure 400 {object} resp.Response "Bad Request"
+// @Failure 404 {object} resp.Response "Not Found - Song not found"
+// @Failure 409 {object} resp.Response "Conflict - Target artist already has a song with this title"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /songs/{id} [put]
+func NewByID(log *slog.Logger, songUpdater SongByIDUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.songs.update.NewByID"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := param.ID(r)
+		if err != nil {
+			log.Error("invalid song id", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("invalid song id"))
+
+			return
+		}
+
+		var req models.SongChanges
+
+		err = render.DecodeJSON(r.Body, &req)
+		if errors.Is(err, io.EOF) {
+			log.Error("request body is empty")
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("bad request"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("bad request"))
+
+			return
+		}
+
+		log.Debug("request body decoded", slog.Any("request", req))
+
+		if req.Artist == "" && req.Title == "" && req.Text == "" && req.Link == "" && req.ReleaseDate == "" &&
+			req.Genres == nil && req.Tags == nil && req.Artists == nil {
+			log.Error("nothing to change")
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("nothing to change"))
+
+			return
+		}
+
+		upd := storage.SongUpdate{
+			Artist: req.Artist,
+			Title:  req.Title,
+		}
+		if req.Text != "" {
+			upd.Lyrics = &req.Text
+		}
+		if req.Link != "" {
+			upd.Link = &req.Link
+		}
+		if req.Genres != nil {
+			upd.Genres = &req.Genres
+		}
+		if req.Tags != nil {
+			upd.Tags = &req.Tags
+		}
+		if req.Artists != nil {
+			upd.Credits = credits(req.Artists)
+		}
+
+		if req.ReleaseDate != "" {
+			releaseDate, err := time.Parse("02.01.2006", req.ReleaseDate)
+			if err != nil {
+				log.Error("failed to parse song release date", sl.Err(err))
+
+				w.WriteHeader(http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("bad release date"))
+
+				return
+			}
+			upd.ReleaseDate = &releaseDate
+		}
+
+		err = songUpdater.UpdateSongByID(r.Context(), id, &upd)
+		if errors.Is(err, storage.ErrSongNotFound) {
+			log.Error("song not found", sl.Err(err))
+
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, resp.Error("song not found"))
+
+			return
+		}
+		if errors.Is(err, storage.ErrSongExists) {
+			log.Error("song already exists", sl.Err(err))
+
+			w.WriteHeader(http.StatusConflict)
+			render.JSON(w, r, resp.Error("song already exists"))
+
+			return
+		}
+		if errors.Is(err, storage.ErrInvalidTag) {
+			log.Error("invalid tag", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.InvalidTag))
+
+			return
+		}
+		if errors.Is(err, storage.ErrInvalidCredit) {
+			log.Error("invalid credit", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(resp.InvalidCredit))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to update song", sl.Err(err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Debug("song updated", slog.Int("id", id))
+
+		render.JSON(w, r, resp.OK())
+	}
+}
@@ -0,0 +1,91 @@
+package suggest
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"song-library/internal/lib/api/resp"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+const (
+	defaultLimit = 10
+	maxLimit     = 50
+)
+
+type Suggester interface {
+	Suggest(ctx context.Context, kind storage.SuggestKind, prefix string, limit int) ([]*storage.Suggestion, error)
+}
+
+// @Summary Autocomplete artist names or song titles.
+// @Description Returns names starting with the prefix first, then names containing it. Song suggestions also carry the artist.
+// @Tags suggest
+// @Accept  json
+// @Produce  json
+// @Param prefix query string true "Beginning of the name" Example("mu")
+// @Param kind query string false "What to complete" Enums(artist, song) Default(artist)
+// @Param limit query int false "Maximum number of suggestions (up to 50)" Default(10)
+// @Success 200 {array} models.Suggestion "Suggestions"
+// @Failure 400 {object} resp.Response "Bad Request"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /suggest [get]
+func New(log *slog.Logger, suggester Suggester) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.suggest.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		prefix := r.URL.Query().Get("prefix")
+		if prefix == "" {
+			log.Info("empty prefix")
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("prefix is required"))
+
+			return
+		}
+
+		kind, err := storage.ParseSuggestKind(r.URL.Query().Get("kind"))
+		if err != nil {
+			log.Info("invalid kind", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error(err.Error()))
+
+			return
+		}
+
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil || limit <= 0 {
+			limit = defaultLimit
+		}
+		limit = min(limit, maxLimit)
+
+		suggestions, err := suggester.Suggest(r.Context(), kind, prefix, limit)
+		if err != nil {
+			log.Error("failed to suggest", sl.Err(err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		response := make([]models.Suggestion, len(suggestions))
+		for i, sg := range suggestions {
+			response[i] = models.Suggestion{ID: sg.ID, Name: sg.Name, Artist: sg.Artist}
+		}
+
+		render.JSON(w, r, response)
+	}
+}
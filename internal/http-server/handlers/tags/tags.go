@@ -0,0 +1,71 @@
+package tags
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"song-library/internal/lib/api/resp"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type TagsLister interface {
+	ListTags(ctx context.Context, kind storage.TagKind, limit, offset int) ([]*storage.TagUsage, error)
+}
+
+// @Summary List tags or genres.
+// @Description Lists the tags (or genres) in use with the number of songs labelled with each, most used first.
+// @Tags tags
+// @Accept  json
+// @Produce  json
+// @Param limit query int false "Limit of names to retrieve" Default(50)
+// @Param offset query int false "Offset for pagination" Default(0)
+// @Success 200 {array} models.TagUsage "Names with usage counts"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /tags [get]
+// @Router /genres [get]
+func New(log *slog.Logger, lister TagsLister, kind storage.TagKind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.tags.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			slog.String("kind", string(kind)),
+		)
+
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil || limit <= 0 {
+			limit = 50
+		}
+		offset, err := strconv.Atoi(r.URL.Query().Get("offset"))
+		if err != nil || offset < 0 {
+			offset = 0
+		}
+
+		usages, err := lister.ListTags(r.Context(), kind, limit, offset)
+		if err != nil {
+			log.Error("failed to list tags", sl.Err(err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		response := make([]models.TagUsage, len(usages))
+		for i, usage := range usages {
+			response[i] = models.TagUsage{Name: usage.Name, SongCount: usage.SongCount}
+		}
+
+		log.Debug("tags fetched", slog.Int("limit", limit), slog.Int("offset", offset))
+
+		render.JSON(w, r, response)
+	}
+}
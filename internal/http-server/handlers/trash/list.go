@@ -0,0 +1,74 @@
+package trash
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"song-library/internal/lib/api/resp"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type TrashLister interface {
+	ListTrash(ctx context.Context, limit, offset int) ([]*storage.TrashedSong, error)
+}
+
+// @Summary List deleted songs.
+// @Description Lists the songs in the trash, most recently deleted first. They stay restorable until the configured retention purges them.
+// @Tags trash
+// @Accept  json
+// @Produce  json
+// @Param limit query int false "Limit of songs to retrieve" Default(20)
+// @Param offset query int false "Offset for pagination" Default(0)
+// @Success 200 {array} models.TrashedSong "Deleted songs"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /trash [get]
+func New(log *slog.Logger, lister TrashLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.trash.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil || limit <= 0 {
+			limit = 20
+		}
+		offset, err := strconv.Atoi(r.URL.Query().Get("offset"))
+		if err != nil || offset < 0 {
+			offset = 0
+		}
+
+		songs, err := lister.ListTrash(r.Context(), limit, offset)
+		if err != nil {
+			log.Error("failed to list trash", sl.Err(err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		response := make([]models.TrashedSong, len(songs))
+		for i, song := range songs {
+			response[i] = models.TrashedSong{
+				ID:        song.ID,
+				Artist:    song.Artist,
+				Title:     song.Title,
+				DeletedAt: song.DeletedAt,
+			}
+		}
+
+		log.Debug("trash fetched", slog.Int("limit", limit), slog.Int("offset", offset))
+
+		render.JSON(w, r, response)
+	}
+}
@@ -0,0 +1,84 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"song-library/internal/lib/api/param"
+	"song-library/internal/lib/api/resp"
+	"song-library/internal/lib/logger/sl"
+	"song-library/internal/storage"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+type SongRestorer interface {
+	RestoreSong(ctx context.Context, id int) error
+}
+
+// @Summary Restore a deleted song.
+// @Description Takes a song out of the trash as it was deleted, album tracks included, and records the restore as a revision. The X-User header names the author.
+// @Tags trash
+// @Accept  json
+// @Produce  json
+// @Param id path int true "Song ID" Example(1)
+// @Param X-User header string false "Author recorded in the revision"
+// @Success 200 {object} resp.Response "Song restored"
+// @Failure 400 {object} resp.Response "Bad Request - Invalid ID"
+// @Failure 404 {object} resp.Response "Not Found - Song not in the trash"
+// @Failure 409 {object} resp.Response "Conflict - The artist already has another song with this title"
+// @Failure 500 {object} resp.Response "Internal Server Error"
+// @Router /trash/{id}/restore [post]
+func NewRestore(log *slog.Logger, restorer SongRestorer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.trash.NewRestore"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := param.ID(r)
+		if err != nil {
+			log.Error("invalid song id", sl.Err(err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, resp.Error("invalid song id"))
+
+			return
+		}
+
+		err = restorer.RestoreSong(r.Context(), id)
+		if errors.Is(err, storage.ErrSongNotFound) {
+			log.Error("song not in trash", sl.Err(err))
+
+			w.WriteHeader(http.StatusNotFound)
+			render.JSON(w, r, resp.Error("song not in trash"))
+
+			return
+		}
+		if errors.Is(err, storage.ErrSongExists) {
+			log.Error("song already exists", sl.Err(err))
+
+			w.WriteHeader(http.StatusConflict)
+			render.JSON(w, r, resp.Error("song already exists"))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to restore song", sl.Err(err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+
+			return
+		}
+
+		log.Debug("song restored", slog.Int("id", id))
+
+		render.JSON(w, r, resp.OK())
+	}
+}
@@ -0,0 +1,36 @@
+package mwAuthor
+
+import (
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"song-library/internal/storage"
+)
+
+// Header names the author of a change. The service has no authentication,
+// so it is taken on trust and only recorded in song revisions.
+const Header = "X-User"
+
+// maxLength is the longest author the song_revisions table stores.
+const maxLength = 100
+
+// New stores the author from the X-User header in the request context,
+// where storage.Author finds it.
+func New() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			author := strings.TrimSpace(r.Header.Get(Header))
+			if utf8.RuneCountInString(author) > maxLength {
+				author = string([]rune(author)[:maxLength])
+			}
+			if author != "" {
+				r = r.WithContext(storage.WithAuthor(r.Context(), author))
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
@@ -0,0 +1,25 @@
+package param
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+var ErrInvalidID = errors.New("invalid id")
+
+// ID returns the positive integer from the {id} URL parameter.
+func ID(r *http.Request) (int, error) {
+	return PositiveInt(r, "id")
+}
+
+// PositiveInt returns the URL parameter key parsed as a positive integer.
+func PositiveInt(r *http.Request, key string) (int, error) {
+	id, err := strconv.Atoi(chi.URLParam(r, key))
+	if err != nil || id <= 0 {
+		return 0, ErrInvalidID
+	}
+	return id, nil
+}
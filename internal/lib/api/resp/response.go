@@ -16,6 +16,12 @@ const (
 	StatusError = "Error"
 )
 
+// Messages explaining storage errors about invalid input to the client.
+const (
+	InvalidCredit = "artists need a name and a featured, producer or composer role; group is the primary artist"
+	InvalidTag    = "genres and tags must not be blank or too long"
+)
+
 func OK() Response {
 	return Response{
 		Status: StatusOk,
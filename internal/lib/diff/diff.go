@@ -0,0 +1,246 @@
+// Package diff compares lyrics line by line and renders the changes as
+// hunks or as a unified diff.
+package diff
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Stored lyrics escape their line breaks: verses are separated by
+// VerseSeparator, as in storage.VerseSeparator, and the lines of a verse
+// by LineSeparator.
+const (
+	VerseSeparator = "\\n\\n"
+	LineSeparator  = "\\n"
+)
+
+// Op tells whether a diff line is kept, added or removed.
+type Op string
+
+const (
+	Keep   Op = "keep"
+	Add    Op = "add"
+	Remove Op = "remove"
+)
+
+// Line is a line of a lyrics diff. Verse is the 1-based verse of the
+// line, in the new lyrics for kept and added lines and in the old ones for
+// removed lines; 0 marks the blank line between two verses. Old and New are
+// the 1-based line numbers, 0 on the side the line is missing from.
+type Line struct {
+	Op    Op
+	Text  string
+	Verse int
+	Old   int
+	New   int
+}
+
+// Hunk is a run of changed lines with the kept lines around them.
+// OldStart and NewStart are the numbers of the first line of the hunk on
+// each side, or of the line it is inserted before if it has none there.
+// Verse is the verse the first change falls in.
+type Hunk struct {
+	OldStart, OldLines int
+	NewStart, NewLines int
+	Verse              int
+	Lines              []Line
+}
+
+// token is a line of lyrics; verse 0 is the break between verses.
+type token struct {
+	text  string
+	verse int
+}
+
+func (t token) equal(o token) bool {
+	return t.text == o.text && (t.verse == 0) == (o.verse == 0)
+}
+
+// splitLyrics splits lyrics into verses the way storage.PaginateVerses does, and
+// the verses into lines.
+func splitLyrics(lyrics string) []token {
+	if lyrics == "" {
+		return nil
+	}
+
+	var tokens []token
+	for i, verse := range strings.Split(lyrics, VerseSeparator) {
+		if i > 0 {
+			tokens = append(tokens, token{})
+		}
+		for _, line := range strings.Split(verse, LineSeparator) {
+			tokens = append(tokens, token{text: line, verse: i + 1})
+		}
+	}
+	return tokens
+}
+
+// Lyrics compares two lyrics line by line, keeping verse breaks aligned,
+// and groups the changes into hunks with up to context kept lines around
+// each. Equal lyrics have no hunks.
+func Lyrics(from, to string, context int) []Hunk {
+	lines := editScript(splitLyrics(from), splitLyrics(to))
+
+	var hunks []Hunk
+	for i := 0; i < len(lines); {
+		if lines[i].Op == Keep {
+			i++
+			continue
+		}
+
+		start := max(i-context, 0)
+		end := i + 1
+		for j := i + 1; j < len(lines); j++ {
+			if lines[j].Op != Keep {
+				end = j + 1
+			} else if j-end >= 2*context {
+				break
+			}
+		}
+		stop := min(end+context, len(lines))
+
+		hunks = append(hunks, newHunk(lines[:start], lines[start:stop]))
+		i = stop
+	}
+	return hunks
+}
+
+// newHunk builds the hunk of lines, which follow the lines before.
+func newHunk(before, lines []Line) Hunk {
+	hunk := Hunk{OldStart: 1, NewStart: 1, Lines: slices.Clone(lines)}
+	for _, l := range before {
+		if l.Op != Add {
+			hunk.OldStart++
+		}
+		if l.Op != Remove {
+			hunk.NewStart++
+		}
+	}
+
+	for _, l := range lines {
+		if l.Op != Add {
+			hunk.OldLines++
+		}
+		if l.Op != Remove {
+			hunk.NewLines++
+		}
+		if hunk.Verse == 0 && l.Op != Keep {
+			hunk.Verse = l.Verse
+		}
+	}
+	for _, l := range lines {
+		if hunk.Verse == 0 {
+			hunk.Verse = l.Verse
+		}
+	}
+	return hunk
+}
+
+// editScript returns the shortest edit script turning a into b, found with
+// Myers' algorithm.
+func editScript(a, b []token) []Line {
+	n, m := len(a), len(b)
+	limit := n + m
+	off := limit + 1
+	v := make([]int, 2*limit+3)
+
+	// trace[d] holds the furthest x reached on diagonals -d..d before step d.
+	var trace [][]int
+search:
+	for d := 0; d <= limit; d++ {
+		trace = append(trace, slices.Clone(v[off-d:off+d+1]))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[off+k-1] < v[off+k+1]) {
+				x = v[off+k+1]
+			} else {
+				x = v[off+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x].equal(b[y]) {
+				x++
+				y++
+			}
+			v[off+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	var lines []Line
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		prevX, prevY := 0, 0
+		if d > 0 {
+			prev := trace[d]
+			at := func(k int) int { return prev[k+d] }
+
+			k := x - y
+			prevK := k - 1
+			if k == -d || (k != d && at(k-1) < at(k+1)) {
+				prevK = k + 1
+			}
+			prevX = at(prevK)
+			prevY = prevX - prevK
+		}
+
+		for x > prevX && y > prevY {
+			lines = append(lines, Line{Op: Keep, Text: b[y-1].text, Verse: b[y-1].verse, Old: x, New: y})
+			x--
+			y--
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			lines = append(lines, Line{Op: Add, Text: b[y-1].text, Verse: b[y-1].verse, New: y})
+		} else {
+			lines = append(lines, Line{Op: Remove, Text: a[x-1].text, Verse: a[x-1].verse, Old: x})
+		}
+		x, y = prevX, prevY
+	}
+
+	slices.Reverse(lines)
+	return lines
+}
+
+// Unified renders hunks as a unified diff between the lyrics labelled
+// from and to, one lyrics line per text line. Each hunk header names the
+// verse of its first change.
+func Unified(from, to string, hunks []Hunk) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", from, to)
+
+	for _, h := range hunks {
+		fmt.Fprintf(&b, "@@ -%s +%s @@ verse %d\n",
+			unifiedRange(h.OldStart, h.OldLines), unifiedRange(h.NewStart, h.NewLines), h.Verse)
+		for _, l := range h.Lines {
+			switch l.Op {
+			case Add:
+				b.WriteByte('+')
+			case Remove:
+				b.WriteByte('-')
+			default:
+				b.WriteByte(' ')
+			}
+			b.WriteString(l.Text)
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+// unifiedRange formats a hunk range; an empty range names the line before.
+func unifiedRange(start, lines int) string {
+	switch lines {
+	case 0:
+		return fmt.Sprintf("%d,0", start-1)
+	case 1:
+		return fmt.Sprintf("%d", start)
+	default:
+		return fmt.Sprintf("%d,%d", start, lines)
+	}
+}
@@ -0,0 +1,111 @@
+package diff
+
+import "testing"
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		context  int
+		want     string
+	}{
+		{
+			name:    "equal",
+			from:    "One\\nTwo",
+			to:      "One\\nTwo",
+			context: 3,
+			want:    "",
+		},
+		{
+			name:    "appended verse",
+			from:    "Paranoia is in bloom\\n\\nThey will not force us",
+			to:      "Paranoia is in bloom\\n\\nThey will not force us\\nThey will stop degrading us\\n\\nWe will be victorious",
+			context: 1,
+			want: "@@ -3 +3,4 @@ verse 2\n" +
+				" They will not force us\n" +
+				"+They will stop degrading us\n" +
+				"+\n" +
+				"+We will be victorious\n",
+		},
+		{
+			name:    "removed verse",
+			from:    "Verse one\\n\\nVerse two\\n\\nVerse three",
+			to:      "Verse one\\n\\nVerse three",
+			context: 1,
+			want: "@@ -2,4 +2,2 @@ verse 2\n" +
+				" \n" +
+				"-Verse two\n" +
+				"-\n" +
+				" Verse three\n",
+		},
+		{
+			name:    "change at a verse boundary",
+			from:    "One\\nTwo\\n\\nThree",
+			to:      "One\\nSecond\\n\\nThree",
+			context: 1,
+			want: "@@ -1,3 +1,3 @@ verse 1\n" +
+				" One\n" +
+				"-Two\n" +
+				"+Second\n" +
+				" \n",
+		},
+		{
+			name:    "empty from",
+			from:    "",
+			to:      "One\\nTwo",
+			context: 3,
+			want: "@@ -0,0 +1,2 @@ verse 1\n" +
+				"+One\n" +
+				"+Two\n",
+		},
+		{
+			name:    "empty to",
+			from:    "One",
+			to:      "",
+			context: 3,
+			want: "@@ -1 +0,0 @@ verse 1\n" +
+				"-One\n",
+		},
+		{
+			name:    "distant changes",
+			from:    "1\\n2\\n3\\n4\\n5\\n6\\n7",
+			to:      "1\\nB\\n3\\n4\\n5\\nF\\n7",
+			context: 1,
+			want: "@@ -1,3 +1,3 @@ verse 1\n" +
+				" 1\n" +
+				"-2\n" +
+				"+B\n" +
+				" 3\n" +
+				"@@ -5,3 +5,3 @@ verse 1\n" +
+				" 5\n" +
+				"-6\n" +
+				"+F\n" +
+				" 7\n",
+		},
+		{
+			name:    "changes merged within context",
+			from:    "1\\n2\\n3\\n4\\n5\\n6\\n7",
+			to:      "1\\nB\\n3\\n4\\n5\\nF\\n7",
+			context: 2,
+			want: "@@ -1,7 +1,7 @@ verse 1\n" +
+				" 1\n" +
+				"-2\n" +
+				"+B\n" +
+				" 3\n" +
+				" 4\n" +
+				" 5\n" +
+				"-6\n" +
+				"+F\n" +
+				" 7\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := "--- from\n+++ to\n" + tt.want
+			if got := Unified("from", "to", Lyrics(tt.from, tt.to, tt.context)); got != want {
+				t.Errorf("Unified =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
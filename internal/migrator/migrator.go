@@ -0,0 +1,322 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// lockID is the advisory lock key that serializes concurrent migrators.
+const lockID = 7_141_592_653
+
+var (
+	ErrUnknownVersion = errors.New("unknown migration version")
+	ErrNoMigrations   = errors.New("no migrations applied")
+)
+
+var fileNameRe = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Status struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Migrator applies embedded SQL migrations and records them in schema_migrations.
+type Migrator struct {
+	conn       *pgx.Conn
+	migrations []Migration
+}
+
+// New connects to the database and loads migrations from fsys.
+func New(ctx context.Context, connectionString string, fsys fs.FS) (*Migrator, error) {
+	const op = "migrator.New"
+
+	migrations, err := load(fsys)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	conn, err := pgx.Connect(ctx, connectionString)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Migrator{conn: conn, migrations: migrations}, nil
+}
+
+func (m *Migrator) Close(ctx context.Context) error {
+	return m.conn.Close(ctx)
+}
+
+// Up applies all pending migrations and returns the versions it applied.
+func (m *Migrator) Up(ctx context.Context) ([]int, error) {
+	const op = "migrator.Up"
+
+	var applied []int
+	err := m.locked(ctx, func(current int) error {
+		for _, mig := range m.migrations {
+			if mig.Version <= current {
+				continue
+			}
+			if err := m.apply(ctx, mig, true); err != nil {
+				return err
+			}
+			applied = append(applied, mig.Version)
+		}
+		return nil
+	})
+	if err != nil {
+		return applied, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return applied, nil
+}
+
+// Down rolls back the latest applied migration and returns its version.
+func (m *Migrator) Down(ctx context.Context) (int, error) {
+	const op = "migrator.Down"
+
+	var reverted int
+	err := m.locked(ctx, func(current int) error {
+		if current == 0 {
+			return ErrNoMigrations
+		}
+		mig, ok := m.find(current)
+		if !ok {
+			return fmt.Errorf("%w: %d", ErrUnknownVersion, current)
+		}
+		reverted = current
+		return m.apply(ctx, mig, false)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return reverted, nil
+}
+
+// To migrates up or down until version is the latest applied migration.
+// Version 0 reverts every migration.
+func (m *Migrator) To(ctx context.Context, version int) error {
+	const op = "migrator.To"
+
+	if _, ok := m.find(version); !ok && version != 0 {
+		return fmt.Errorf("%s: %w: %d", op, ErrUnknownVersion, version)
+	}
+
+	err := m.locked(ctx, func(current int) error {
+		for _, mig := range m.migrations {
+			if mig.Version > current && mig.Version <= version {
+				if err := m.apply(ctx, mig, true); err != nil {
+					return err
+				}
+			}
+		}
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			mig := m.migrations[i]
+			if mig.Version <= current && mig.Version > version {
+				if err := m.apply(ctx, mig, false); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Status lists every known migration and whether it has been applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	const op = "migrator.Status"
+
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := m.conn.Query(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	appliedAt := make(map[int]time.Time)
+	for rows.Next() {
+		var (
+			version int
+			at      time.Time
+		)
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		appliedAt[version] = at
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	statuses := make([]Status, len(m.migrations))
+	for i, mig := range m.migrations {
+		at, ok := appliedAt[mig.Version]
+		statuses[i] = Status{
+			Version:   mig.Version,
+			Name:      mig.Name,
+			Applied:   ok,
+			AppliedAt: at,
+		}
+	}
+
+	return statuses, nil
+}
+
+// locked runs fn while holding the advisory lock, passing the current schema version.
+func (m *Migrator) locked(ctx context.Context, fn func(current int) error) error {
+	if _, err := m.conn.Exec(ctx, "SELECT pg_advisory_lock($1)", lockID); err != nil {
+		return fmt.Errorf("acquire lock: %w", err)
+	}
+	defer m.conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", lockID)
+
+	if err := m.ensureTable(ctx); err != nil {
+		return err
+	}
+
+	var current int
+	err := m.conn.QueryRow(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&current)
+	if err != nil {
+		return fmt.Errorf("read version: %w", err)
+	}
+
+	return fn(current)
+}
+
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	_, err := m.conn.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)`)
+	if err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+	return nil
+}
+
+// apply runs a single migration in its own transaction.
+func (m *Migrator) apply(ctx context.Context, mig Migration, up bool) error {
+	tx, err := m.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if up {
+		if _, err := tx.Exec(ctx, mig.Up); err != nil {
+			return fmt.Errorf("apply %d_%s: %w", mig.Version, mig.Name, err)
+		}
+		_, err = tx.Exec(ctx, "INSERT INTO schema_migrations(version, name) VALUES ($1, $2)", mig.Version, mig.Name)
+	} else {
+		if _, err := tx.Exec(ctx, mig.Down); err != nil {
+			return fmt.Errorf("revert %d_%s: %w", mig.Version, mig.Name, err)
+		}
+		_, err = tx.Exec(ctx, "DELETE FROM schema_migrations WHERE version = $1", mig.Version)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (m *Migrator) find(version int) (Migration, bool) {
+	for _, mig := range m.migrations {
+		if mig.Version == version {
+			return mig, true
+		}
+	}
+	return Migration{}, false
+}
+
+// load reads the migrations in the root of fsys, ordered by version. Every
+// version needs exactly one up and one down file; other files are ignored.
+func load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	files := make(map[string]string) // "<version>.<direction>" to file name
+	for _, entry := range entries {
+		match := fileNameRe.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("parse version of %s: %w", entry.Name(), err)
+		}
+
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = mig
+		}
+		if mig.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, mig.Name, match[2])
+		}
+
+		key := fmt.Sprintf("%d.%s", version, match[3])
+		if other, ok := files[key]; ok {
+			return nil, fmt.Errorf("migration %d has two %s files: %s and %s", version, match[3], other, entry.Name())
+		}
+		files[key] = entry.Name()
+
+		if match[3] == "up" {
+			mig.Up = string(body)
+		} else {
+			mig.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		for _, direction := range []string{"up", "down"} {
+			if _, ok := files[fmt.Sprintf("%d.%s", mig.Version, direction)]; !ok {
+				return nil, fmt.Errorf("migration %d_%s has no %s file", mig.Version, mig.Name, direction)
+			}
+		}
+		migrations = append(migrations, *mig)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
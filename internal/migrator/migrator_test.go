@@ -0,0 +1,237 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"song-library/migrations"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func files(names ...string) fstest.MapFS {
+	fsys := make(fstest.MapFS, len(names))
+	for _, name := range names {
+		fsys[name] = &fstest.MapFile{Data: []byte("-- " + name)}
+	}
+	return fsys
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		want    []int
+		wantErr bool
+	}{
+		{
+			name: "numeric order",
+			fsys: files("10_ten.up.sql", "10_ten.down.sql", "2_two.up.sql", "2_two.down.sql", "1_one.up.sql", "1_one.down.sql"),
+			want: []int{1, 2, 10},
+		},
+		{
+			name: "other files ignored",
+			fsys: files("1_one.up.sql", "1_one.down.sql", "insert_data.pgsql", "abc_one.up.sql", "1_one.sql", "migrations.go"),
+			want: []int{1},
+		},
+		{
+			name: "leading zeros",
+			fsys: files("002_two.up.sql", "002_two.down.sql"),
+			want: []int{2},
+		},
+		{name: "empty", fsys: fstest.MapFS{}, want: []int{}},
+		{name: "missing down", fsys: files("1_one.up.sql", "1_one.down.sql", "2_two.up.sql"), wantErr: true},
+		{name: "missing up", fsys: files("1_one.down.sql"), wantErr: true},
+		{name: "duplicate version", fsys: files("1_one.up.sql", "1_one.down.sql", "01_one.up.sql"), wantErr: true},
+		{name: "conflicting names", fsys: files("1_one.up.sql", "1_one.down.sql", "1_uno.up.sql", "1_uno.down.sql"), wantErr: true},
+		{name: "version overflow", fsys: files("99999999999999999999_big.up.sql", "99999999999999999999_big.down.sql"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migs, err := load(tt.fsys)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("load = %+v, want an error", migs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("load: %v", err)
+			}
+
+			versions := make([]int, len(migs))
+			for i, mig := range migs {
+				versions[i] = mig.Version
+				if !strings.HasSuffix(mig.Up, mig.Name+".up.sql") || !strings.HasSuffix(mig.Down, mig.Name+".down.sql") {
+					t.Errorf("migration %d = %+v, bodies do not match its files", mig.Version, mig)
+				}
+			}
+			if !slices.Equal(versions, tt.want) {
+				t.Errorf("versions = %v, want %v", versions, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadEmbedded(t *testing.T) {
+	migs, err := load(migrations.FS)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	for i, mig := range migs {
+		if mig.Version != i+1 {
+			t.Errorf("migration %d_%s found at position %d, want versions 1..%d in order", mig.Version, mig.Name, i+1, len(migs))
+		}
+	}
+}
+
+// testSchema isolates the migrator tests from the storage tests sharing the
+// database.
+const testSchema = "migrator_test"
+
+// newTestMigrator returns a migrator over fsys working in an empty
+// testSchema of the database in TEST_CONNECTION_STRING.
+func newTestMigrator(t *testing.T, fsys fs.FS) *Migrator {
+	t.Helper()
+
+	connectionString := os.Getenv("TEST_CONNECTION_STRING")
+	if connectionString == "" {
+		t.Skip("TEST_CONNECTION_STRING is not set")
+	}
+	ctx := context.Background()
+
+	config, err := pgx.ParseConfig(connectionString)
+	if err != nil {
+		t.Fatalf("parse connection string: %v", err)
+	}
+	config.RuntimeParams["search_path"] = testSchema + ", public"
+
+	conn, err := pgx.ConnectConfig(ctx, config)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() {
+		conn.Exec(context.Background(), "DROP SCHEMA IF EXISTS "+testSchema+" CASCADE")
+		conn.Close(context.Background())
+	})
+
+	_, err = conn.Exec(ctx, "DROP SCHEMA IF EXISTS "+testSchema+" CASCADE; CREATE SCHEMA "+testSchema)
+	if err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+
+	migs, err := load(fsys)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	return &Migrator{conn: conn, migrations: migs}
+}
+
+// table returns migration files creating and dropping a table.
+func table(name string) (up, down *fstest.MapFile) {
+	return &fstest.MapFile{Data: []byte("CREATE TABLE " + name + " (id INT)")},
+		&fstest.MapFile{Data: []byte("DROP TABLE " + name)}
+}
+
+// assertTables checks which of the tables one, two and ten exist.
+func assertTables(t *testing.T, m *Migrator, want ...string) {
+	t.Helper()
+
+	var got []string
+	for _, name := range []string{"one", "two", "ten"} {
+		var exists bool
+		err := m.conn.QueryRow(context.Background(), "SELECT to_regclass($1) IS NOT NULL", testSchema+"."+name).Scan(&exists)
+		if err != nil {
+			t.Fatalf("check table %s: %v", name, err)
+		}
+		if exists {
+			got = append(got, name)
+		}
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("tables = %v, want %v", got, want)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	fsys := fstest.MapFS{}
+	for _, name := range []string{"1_one", "2_two", "10_ten"} {
+		fsys[name+".up.sql"], fsys[name+".down.sql"] = table(strings.SplitN(name, "_", 2)[1])
+	}
+	m := newTestMigrator(t, fsys)
+	ctx := context.Background()
+
+	applied, err := m.Up(ctx)
+	if err != nil || !slices.Equal(applied, []int{1, 2, 10}) {
+		t.Fatalf("Up = %v, %v, want [1 2 10]", applied, err)
+	}
+	assertTables(t, m, "one", "two", "ten")
+	if applied, err := m.Up(ctx); err != nil || len(applied) != 0 {
+		t.Errorf("Up again = %v, %v, want nothing applied", applied, err)
+	}
+
+	if reverted, err := m.Down(ctx); err != nil || reverted != 10 {
+		t.Fatalf("Down = %d, %v, want 10", reverted, err)
+	}
+	assertTables(t, m, "one", "two")
+
+	if err := m.To(ctx, 1); err != nil {
+		t.Fatalf("To(1): %v", err)
+	}
+	assertTables(t, m, "one")
+
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	var versions []int
+	for _, status := range statuses {
+		if status.Applied {
+			versions = append(versions, status.Version)
+		}
+	}
+	if len(statuses) != 3 || !slices.Equal(versions, []int{1}) {
+		t.Errorf("Status = %+v, want 3 migrations with only 1 applied", statuses)
+	}
+
+	if err := m.To(ctx, 10); err != nil {
+		t.Fatalf("To(10): %v", err)
+	}
+	assertTables(t, m, "one", "two", "ten")
+
+	if err := m.To(ctx, 0); err != nil {
+		t.Fatalf("To(0): %v", err)
+	}
+	assertTables(t, m)
+
+	if _, err := m.Down(ctx); !errors.Is(err, ErrNoMigrations) {
+		t.Errorf("Down(nothing applied) error = %v, want ErrNoMigrations", err)
+	}
+	if err := m.To(ctx, 5); !errors.Is(err, ErrUnknownVersion) {
+		t.Errorf("To(5) error = %v, want ErrUnknownVersion", err)
+	}
+}
+
+// TestMigrations checks that every embedded migration applies and reverts.
+func TestMigrations(t *testing.T) {
+	m := newTestMigrator(t, migrations.FS)
+	ctx := context.Background()
+
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	if err := m.To(ctx, 0); err != nil {
+		t.Fatalf("To(0): %v", err)
+	}
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatalf("Up after reverting everything: %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package models
+
+type Album struct {
+	ID          int      `json:"id" example:"1"`
+	Artist      string   `json:"group" example:"Muse"`
+	Title       string   `json:"title" example:"Black Holes and Revelations"`
+	ReleaseDate string   `json:"release_date,omitempty" example:"03.07.2006"`
+	CoverLink   string   `json:"cover_link,omitempty" example:"https://example.com/covers/bhar.jpg"`
+	TrackCount  int      `json:"track_count" example:"11"`
+	Tracks      []*Track `json:"tracks,omitempty"`
+}
+
+type Track struct {
+	Number int    `json:"number" example:"3"`
+	SongID int    `json:"song_id" example:"1"`
+	Artist string `json:"group" example:"Muse"`
+	Title  string `json:"song" example:"Supermassive Black Hole"`
+}
+
+// AlbumRequest is the body of POST /albums and PUT /albums/{id}. PUT
+// replaces the whole album, track listing included.
+type AlbumRequest struct {
+	Artist      string         `json:"group" validate:"required" example:"Muse"`
+	Title       string         `json:"title" validate:"required" example:"Black Holes and Revelations"`
+	ReleaseDate string         `json:"release_date,omitempty" example:"03.07.2006"`
+	CoverLink   string         `json:"cover_link,omitempty" example:"https://example.com/covers/bhar.jpg"`
+	Tracks      []TrackRequest `json:"tracks" validate:"dive"`
+}
+
+type TrackRequest struct {
+	Number int `json:"number" validate:"required,min=1" example:"3"`
+	SongID int `json:"song_id" validate:"required,min=1" example:"1"`
+}
+
+// SongAlbum is an album a song appears on, as shown by GET /info.
+type SongAlbum struct {
+	ID          int    `json:"id" example:"1"`
+	Title       string `json:"title" example:"Black Holes and Revelations"`
+	ReleaseDate string `json:"release_date,omitempty" example:"03.07.2006"`
+	Track       int    `json:"track" example:"3"`
+}
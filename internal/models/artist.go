@@ -0,0 +1,28 @@
+package models
+
+type Artist struct {
+	ID        int    `json:"id" example:"1"`
+	Name      string `json:"name" example:"Muse"`
+	SongCount int    `json:"song_count" example:"2"`
+}
+
+// ArtistRequest is the body of POST /artists and PUT /artists/{id}.
+type ArtistRequest struct {
+	Name string `json:"name" validate:"required" example:"Muse"`
+}
+
+// MergeRequest is the body of POST /artists/{id}/merge.
+type MergeRequest struct {
+	SourceIDs []int `json:"source_ids" validate:"required,min=1,dive,min=1" example:"2,3"`
+}
+
+// MergeResult reports how many songs a merge moved.
+type MergeResult struct {
+	Moved int `json:"moved" example:"5"`
+}
+
+// MergeConflict is a title held by more than one of the merged artists.
+type MergeConflict struct {
+	Title   string `json:"title" example:"Uprising"`
+	SongIDs []int  `json:"song_ids" example:"4,9"`
+}
@@ -0,0 +1,31 @@
+package models
+
+// LyricsDiff is the line-level diff between the lyrics of two revisions,
+// returned by GET /songs/{id}/lyrics/diff.
+type LyricsDiff struct {
+	From  int        `json:"from" example:"1"`
+	To    int        `json:"to" example:"2"`
+	Hunks []DiffHunk `json:"hunks"`
+}
+
+// DiffHunk is a run of changed lines with the kept lines around them. The
+// start fields are 1-based line numbers; verse is the verse of the first change.
+type DiffHunk struct {
+	OldStart int        `json:"old_start" example:"1"`
+	OldLines int        `json:"old_lines" example:"3"`
+	NewStart int        `json:"new_start" example:"1"`
+	NewLines int        `json:"new_lines" example:"4"`
+	Verse    int        `json:"verse" example:"2"`
+	Lines    []DiffLine `json:"lines"`
+}
+
+// DiffLine is a kept, added or removed lyrics line. Verse 0 marks the blank
+// line between two verses; old and new are absent on the side the line is
+// missing from.
+type DiffLine struct {
+	Op    string `json:"op" enums:"keep,add,remove" example:"add"`
+	Text  string `json:"text" example:"They will not degrade us"`
+	Verse int    `json:"verse" example:"2"`
+	Old   int    `json:"old,omitempty" example:"3"`
+	New   int    `json:"new,omitempty" example:"4"`
+}
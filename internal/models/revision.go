@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Revision is a recorded change of a song, as listed by GET /songs/{id}/revisions.
+type Revision struct {
+	Number    int       `json:"rev" example:"2"`
+	Action    string    `json:"action" enums:"add,update,delete,restore" example:"update"`
+	Author    string    `json:"author" example:"alice"`
+	CreatedAt time.Time `json:"created_at" example:"2024-05-01T12:00:00Z"`
+	Artist    string    `json:"group" example:"Muse"`
+	Title     string    `json:"song" example:"Supermassive Black Hole"`
+}
+
+// RevisionDetail is a revision with the song as it was then. A delete
+// revision holds the song as it was before the delete.
+type RevisionDetail struct {
+	Revision
+	Song *Song `json:"snapshot"`
+}
@@ -1,19 +1,107 @@
 package models
 
 type SongDetail struct {
-	ReleaseDate string `json:"release_date,omitempty" example:"16.07.2006"`
-	Text        string `json:"text,omitempty" example:"Ooh baby, don't you know I suffer?\\nOoh baby, canyou hear me moan?\\nYou caught me under false pretenses\\nHow long before you let me go?\\n\\nOoh\\nYou set my soul alight\\nOoh\\nYou set my soul alight"`
-	Link        string `json:"link,omitempty" example:"https://www.youtube.com/watch?v=Xsp3_a-PMTw"`
+	ReleaseDate string       `json:"release_date,omitempty" example:"16.07.2006"`
+	Text        string       `json:"text,omitempty" example:"Ooh baby, don't you know I suffer?\\nOoh baby, canyou hear me moan?\\nYou caught me under false pretenses\\nHow long before you let me go?\\n\\nOoh\\nYou set my soul alight\\nOoh\\nYou set my soul alight"`
+	Link        string       `json:"link,omitempty" example:"https://www.youtube.com/watch?v=Xsp3_a-PMTw"`
+	Albums      []*SongAlbum `json:"albums,omitempty"`
 }
 
 type Song struct {
-	Artist      string `json:"group" validate:"required" example:"Muse"`
-	Title       string `json:"song" validate:"required" example:"Supermassive Black Hole"`
-	ReleaseDate string `json:"release_date,omitempty" example:"16.07.2006"`
-	Text        string `json:"text,omitempty" example:"Ooh baby, don't you know..."`
-	Link        string `json:"link,omitempty" example:"https://www.youtube.com/watch?v=Xsp3_a-PMTw"`
+	ID          int      `json:"id,omitempty" example:"1"`
+	Artist      string   `json:"group" validate:"required" example:"Muse"`
+	Title       string   `json:"song" validate:"required" example:"Supermassive Black Hole"`
+	ReleaseDate string   `json:"release_date,omitempty" example:"16.07.2006"`
+	Text        string   `json:"text,omitempty" example:"Ooh baby, don't you know..."`
+	Link        string   `json:"link,omitempty" example:"https://www.youtube.com/watch?v=Xsp3_a-PMTw"`
+	Genres      []string `json:"genres,omitempty" example:"alternative rock"`
+	Tags        []string `json:"tags,omitempty" example:"live favourite"`
+	// Artists lists every artist of the song, the primary artist first.
+	// Requests give only the other credits.
+	Artists []Credit `json:"artists,omitempty" validate:"dive"`
+	// Score is set on fuzzy matches only.
+	Score float64 `json:"score,omitempty" example:"0.75"`
+}
+
+// Credit is an artist of a song and their role. In requests the role is
+// featured, producer or composer: group is the primary artist.
+type Credit struct {
+	ID   int    `json:"id,omitempty" example:"2"`
+	Name string `json:"name" validate:"required" example:"David Bowie"`
+	Role string `json:"role" validate:"required" enums:"primary,featured,producer,composer" example:"featured"`
+}
+
+// SongPage is the enveloped form of the GET /songs response.
+type SongPage struct {
+	Items      []*Song `json:"items"`
+	Total      int     `json:"total" example:"42"`
+	Limit      int     `json:"limit" example:"10"`
+	Offset     int     `json:"offset" example:"0"`
+	Next       string  `json:"next,omitempty" example:"/songs?limit=10&offset=10"`
+	Prev       string  `json:"prev,omitempty"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+	PrevCursor string  `json:"prev_cursor,omitempty"`
+}
+
+// SearchHit is a song found by GET /songs/search.
+type SearchHit struct {
+	ID          int     `json:"id" example:"1"`
+	Artist      string  `json:"group" example:"Muse"`
+	Title       string  `json:"song" example:"Supermassive Black Hole"`
+	ReleaseDate string  `json:"release_date,omitempty" example:"16.07.2006"`
+	Link        string  `json:"link,omitempty" example:"https://www.youtube.com/watch?v=Xsp3_a-PMTw"`
+	Rank        float64 `json:"rank" example:"0.0607927"`
+	Headline    string  `json:"headline" example:"You set my <mark>soul</mark> alight"`
+}
+
+// Suggestion is an autocomplete entry returned by GET /suggest.
+type Suggestion struct {
+	ID     int    `json:"id" example:"1"`
+	Name   string `json:"name" example:"Muse"`
+	Artist string `json:"group,omitempty" example:"Muse"`
 }
 
 type Lyrics struct {
 	Text string `json:"text,omitempty" example:"Ooh baby, don't you know..."`
 }
+
+// SongUpdate is the body of PUT /songs. Group and song identify the song;
+// new_group and new_song move or rename it. Genres, tags and artists, if
+// present, replace those of the song; an empty list clears them. Artists
+// are the credits other than the primary artist.
+type SongUpdate struct {
+	Artist      string   `json:"group" validate:"required" example:"Muse"`
+	Title       string   `json:"song" validate:"required" example:"Supermassive Black Hole"`
+	NewArtist   string   `json:"new_group,omitempty" example:"Muse"`
+	NewTitle    string   `json:"new_song,omitempty" example:"Supermassive Black Hole"`
+	ReleaseDate string   `json:"release_date,omitempty" example:"16.07.2006"`
+	Text        string   `json:"text,omitempty" example:"Ooh baby, don't you know..."`
+	Link        string   `json:"link,omitempty" example:"https://www.youtube.com/watch?v=Xsp3_a-PMTw"`
+	Genres      []string `json:"genres,omitempty" example:"alternative rock"`
+	Tags        []string `json:"tags,omitempty" example:"live favourite"`
+	Artists     []Credit `json:"artists,omitempty"`
+}
+
+// SongChanges is the body of PUT /songs/{id}. Group and song move or rename
+// the song; genres, tags and artists are replaced as in SongUpdate.
+type SongChanges struct {
+	Artist      string   `json:"group,omitempty" example:"Muse"`
+	Title       string   `json:"song,omitempty" example:"Supermassive Black Hole"`
+	ReleaseDate string   `json:"release_date,omitempty" example:"16.07.2006"`
+	Text        string   `json:"text,omitempty" example:"Ooh baby, don't you know..."`
+	Link        string   `json:"link,omitempty" example:"https://www.youtube.com/watch?v=Xsp3_a-PMTw"`
+	Genres      []string `json:"genres,omitempty" example:"alternative rock"`
+	Tags        []string `json:"tags,omitempty" example:"live favourite"`
+	Artists     []Credit `json:"artists,omitempty"`
+}
+
+// TagsRequest is the body of POST /songs/{id}/tags and POST /songs/{id}/genres.
+type TagsRequest struct {
+	Names []string `json:"names" validate:"required,min=1,dive,required" example:"live favourite"`
+}
+
+// TagUsage is a genre or tag listed by GET /tags and GET /genres.
+type TagUsage struct {
+	Name      string `json:"name" example:"live favourite"`
+	SongCount int    `json:"song_count" example:"3"`
+}
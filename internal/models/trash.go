@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// TrashedSong is a deleted song listed by GET /trash.
+type TrashedSong struct {
+	ID        int       `json:"id" example:"1"`
+	Artist    string    `json:"group" example:"Muse"`
+	Title     string    `json:"song" example:"Supermassive Black Hole"`
+	DeletedAt time.Time `json:"deleted_at" example:"2024-05-01T12:00:00Z"`
+}
@@ -0,0 +1,41 @@
+// Package purger empties the song trash in the background.
+package purger
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"song-library/internal/lib/logger/sl"
+)
+
+type TrashPurger interface {
+	PurgeTrash(ctx context.Context, before time.Time) (int, error)
+}
+
+// Run purges the songs that have been in the trash for longer than
+// retention, right away and then every interval, until ctx is done.
+func Run(ctx context.Context, log *slog.Logger, trash TrashPurger, retention, interval time.Duration) {
+	const op = "purger.Run"
+
+	log = log.With(slog.String("op", op))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := trash.PurgeTrash(ctx, time.Now().Add(-retention))
+		switch {
+		case err != nil && ctx.Err() == nil:
+			log.Error("failed to purge trash", sl.Err(err))
+		case purged > 0:
+			log.Info("trash purged", slog.Int("songs", purged))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
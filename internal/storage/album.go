@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrAlbumNotFound = errors.New("album not found")
+	// ErrInvalidTrack means a track listing repeats a number or a song,
+	// or has a number below 1.
+	ErrInvalidTrack = errors.New("invalid track")
+	// ErrTrackSongNotFound means a track listing names a song that does not exist.
+	ErrTrackSongNotFound = errors.New("track song not found")
+)
+
+// Album is a release of an artist with its track listing. Tracks may be
+// songs of other artists, as on compilations.
+type Album struct {
+	ID int
+	// Artist is matched and created like the artist of a song.
+	Artist      string
+	Title       string
+	ReleaseDate time.Time
+	CoverLink   string
+	// Tracks is ordered by number. ListAlbums leaves it empty and sets
+	// TrackCount only.
+	Tracks     []Track
+	TrackCount int
+}
+
+// CheckTracks returns ErrInvalidTrack unless track numbers are positive
+// and neither numbers nor songs repeat.
+func CheckTracks(tracks []Track) error {
+	numbers := make(map[int]bool, len(tracks))
+	songs := make(map[int]bool, len(tracks))
+	for _, track := range tracks {
+		switch {
+		case track.Number < 1:
+			return fmt.Errorf("%w: number %d is not positive", ErrInvalidTrack, track.Number)
+		case numbers[track.Number]:
+			return fmt.Errorf("%w: number %d repeats", ErrInvalidTrack, track.Number)
+		case songs[track.SongID]:
+			return fmt.Errorf("%w: song %d repeats", ErrInvalidTrack, track.SongID)
+		}
+		numbers[track.Number] = true
+		songs[track.SongID] = true
+	}
+	return nil
+}
+
+// Track is a numbered song of an album. Artist and Title are filled in
+// on reads.
+type Track struct {
+	Number int
+	SongID int
+	Artist string
+	Title  string
+}
+
+// SongAlbum is an album a song appears on, with the number of its track there.
+type SongAlbum struct {
+	ID          int
+	Title       string
+	ReleaseDate time.Time
+	Track       int
+}
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrArtistNotFound = errors.New("artist not found")
+	ErrArtistExists   = errors.New("artist exists")
+	ErrArtistHasSongs = errors.New("artist has songs")
+	ErrMergeConflict  = errors.New("merge conflict")
+)
+
+type Artist struct {
+	ID   int
+	Name string
+	// SongCount is filled in by reads and ignored by writes.
+	SongCount int
+}
+
+// MergeConflict lists songs that would share a title under the merge
+// target. Titles are compared exactly, as the unique constraint does.
+type MergeConflict struct {
+	Title   string
+	SongIDs []int
+}
+
+// MergeConflictError is returned by MergeArtists when songs collide. It
+// wraps ErrMergeConflict; nothing is merged.
+type MergeConflictError struct {
+	Conflicts []MergeConflict
+}
+
+func (e *MergeConflictError) Error() string {
+	return fmt.Sprintf("%s: %d conflicting titles", ErrMergeConflict, len(e.Conflicts))
+}
+
+func (e *MergeConflictError) Unwrap() error {
+	return ErrMergeConflict
+}
+
+// NormalizeArtistName trims name and collapses inner whitespace.
+func NormalizeArtistName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
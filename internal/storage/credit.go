@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidCredit means a credit has a blank artist or a role other than
+// featured, producer or composer.
+var ErrInvalidCredit = errors.New("invalid credit")
+
+// ArtistRole is the part an artist plays on a song.
+type ArtistRole string
+
+const (
+	RolePrimary  ArtistRole = "primary"
+	RoleFeatured ArtistRole = "featured"
+	RoleProducer ArtistRole = "producer"
+	RoleComposer ArtistRole = "composer"
+)
+
+// roleOrder lists the roles in the order credits are returned.
+var roleOrder = []ArtistRole{RolePrimary, RoleFeatured, RoleProducer, RoleComposer}
+
+// Credit names an artist of a song and their role.
+type Credit struct {
+	ArtistID int
+	Artist   string
+	Role     ArtistRole
+}
+
+// RoleRank is the position of role in the order credits are returned:
+// the primary artist first, then featured artists, producers and composers.
+func RoleRank(role ArtistRole) int {
+	for i, r := range roleOrder {
+		if r == role {
+			return i
+		}
+	}
+	return len(roleOrder)
+}
+
+// CheckCredits validates the extra credits of a song. The primary artist
+// is the Artist of the song and cannot be credited this way. Artists are
+// matched and created like the artist of a song; repeated credits are
+// stored once.
+func CheckCredits(credits []Credit) error {
+	for _, c := range credits {
+		if strings.TrimSpace(c.Artist) == "" {
+			return fmt.Errorf("%w: blank artist", ErrInvalidCredit)
+		}
+		if c.Role == RolePrimary || RoleRank(c.Role) == len(roleOrder) {
+			return fmt.Errorf("%w: role %q", ErrInvalidCredit, c.Role)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor marks a position in a sorted song list for keyset pagination.
+// GetSongs returns the songs strictly after it, or strictly before it
+// when Backward is set.
+type Cursor struct {
+	Sort     []SortField
+	Backward bool
+	// Sort key values and ID of the song the cursor points at.
+	ID          int
+	Artist      string
+	Title       string
+	ReleaseDate time.Time
+}
+
+// cursorToken is the JSON form of a cursor. Only the sort keys in use are set.
+type cursorToken struct {
+	Sort        string `json:"s,omitempty"`
+	Backward    bool   `json:"b,omitempty"`
+	ID          int    `json:"i"`
+	Artist      string `json:"a,omitempty"`
+	Title       string `json:"t,omitempty"`
+	ReleaseDate string `json:"d,omitempty"`
+}
+
+const cursorDateLayout = "2006-01-02"
+
+// CursorAt returns a cursor pointing at song in a list ordered by sort.
+func CursorAt(song *Song, sort []SortField, backward bool) Cursor {
+	return Cursor{
+		Sort:        sort,
+		Backward:    backward,
+		ID:          song.ID,
+		Artist:      song.Artist,
+		Title:       song.Title,
+		ReleaseDate: song.ReleaseDate,
+	}
+}
+
+// Encode returns the cursor as an opaque URL-safe token.
+func (c Cursor) Encode() string {
+	token := cursorToken{
+		Sort:     FormatSort(c.Sort),
+		Backward: c.Backward,
+		ID:       c.ID,
+	}
+	for _, field := range c.Sort {
+		switch field.Key {
+		case SortArtist:
+			token.Artist = c.Artist
+		case SortTitle:
+			token.Title = c.Title
+		case SortReleaseDate:
+			token.ReleaseDate = c.ReleaseDate.Format(cursorDateLayout)
+		}
+	}
+
+	// Marshalling a struct of strings, ints and bools cannot fail.
+	data, _ := json.Marshal(token)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a token produced by Cursor.Encode.
+func DecodeCursor(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidCursor)
+	}
+
+	var token cursorToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidCursor)
+	}
+
+	sort, err := ParseSort(token.Sort)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	cursor := &Cursor{
+		Sort:     sort,
+		Backward: token.Backward,
+		ID:       token.ID,
+		Artist:   token.Artist,
+		Title:    token.Title,
+	}
+	if token.ReleaseDate != "" {
+		if cursor.ReleaseDate, err = time.Parse(cursorDateLayout, token.ReleaseDate); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+		}
+	}
+
+	return cursor, nil
+}
+
+// FormatSort is the inverse of ParseSort.
+func FormatSort(sort []SortField) string {
+	parts := make([]string, len(sort))
+	for i, field := range sort {
+		parts[i] = string(field.Key)
+		if field.Desc {
+			parts[i] = "-" + parts[i]
+		}
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"strings"
+	"time"
+)
+
+// Presence filters songs by whether an optional field is filled in.
+type Presence int
+
+const (
+	PresenceAny Presence = iota
+	PresencePresent
+	PresenceAbsent
+)
+
+// FuzzyThreshold is the least trigram similarity of a fuzzy match. It is
+// the default of pg_trgm's similarity_threshold, which the % operator uses.
+const FuzzyThreshold = 0.3
+
+// SongFilter narrows GetSongs. Zero-valued fields match every song.
+type SongFilter struct {
+	// Artist, Title and Lyrics match case-insensitive substrings. Artist
+	// matches any credited artist of the song, whatever the role.
+	Artist string
+	Title  string
+	Lyrics string
+	// ArtistID, if set, keeps only the songs of that primary artist.
+	ArtistID int
+	// Album keeps the songs on an album whose title contains it,
+	// case-insensitively.
+	Album string
+	// Fuzzy matches Artist and Title by trigram similarity instead, and
+	// orders the songs by it, best first. The artist term scores the
+	// best-matching credited artist.
+	Fuzzy bool
+	// ReleasedFrom and ReleasedTo bound the release date inclusively.
+	ReleasedFrom time.Time
+	ReleasedTo   time.Time
+	HasLyrics    Presence
+	HasLink      Presence
+	// Genres and Tags keep the songs labelled with any or all of the names.
+	Genres TagFilter
+	Tags   TagFilter
+}
+
+// EscapeLike escapes LIKE wildcards so s matches literally.
+func EscapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
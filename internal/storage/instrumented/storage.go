@@ -0,0 +1,308 @@
+// Package instrumented wraps storage.Storage with Prometheus metrics.
+package instrumented
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"song-library/internal/storage"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	resultOK       = "ok"
+	resultRejected = "rejected"
+	resultError    = "error"
+)
+
+// Storage times every call to the wrapped storage.
+type Storage struct {
+	next     storage.Storage
+	duration *prometheus.HistogramVec
+}
+
+func New(next storage.Storage, reg prometheus.Registerer) *Storage {
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "song_library",
+		Subsystem: "storage",
+		Name:      "operation_duration_seconds",
+		Help:      "Duration of storage calls by method and result.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "result"})
+
+	reg.MustRegister(duration)
+
+	return &Storage{next: next, duration: duration}
+}
+
+func (s *Storage) observe(method string, t1 time.Time, err error) {
+	s.duration.WithLabelValues(method, result(err)).Observe(time.Since(t1).Seconds())
+}
+
+// result classifies err; expected domain errors are not counted as failures.
+func result(err error) string {
+	switch {
+	case err == nil:
+		return resultOK
+	case errors.Is(err, storage.ErrSongNotFound),
+		errors.Is(err, storage.ErrSongExists),
+		errors.Is(err, storage.NothingChanged),
+		errors.Is(err, storage.ErrArtistNotFound),
+		errors.Is(err, storage.ErrArtistExists),
+		errors.Is(err, storage.ErrArtistHasSongs),
+		errors.Is(err, storage.ErrMergeConflict),
+		errors.Is(err, storage.ErrAlbumNotFound),
+		errors.Is(err, storage.ErrInvalidTrack),
+		errors.Is(err, storage.ErrTrackSongNotFound),
+		errors.Is(err, storage.ErrInvalidTag),
+		errors.Is(err, storage.ErrInvalidCredit),
+		errors.Is(err, storage.ErrRevisionNotFound):
+		return resultRejected
+	default:
+		return resultError
+	}
+}
+
+func (s *Storage) GetSongs(ctx context.Context, filter *storage.SongFilter, page storage.Page) ([]*storage.Song, error) {
+	t1 := time.Now()
+	songs, err := s.next.GetSongs(ctx, filter, page)
+	s.observe("GetSongs", t1, err)
+	return songs, err
+}
+
+func (s *Storage) CountSongs(ctx context.Context, filter *storage.SongFilter) (int, error) {
+	t1 := time.Now()
+	n, err := s.next.CountSongs(ctx, filter)
+	s.observe("CountSongs", t1, err)
+	return n, err
+}
+
+func (s *Storage) SearchLyrics(ctx context.Context, query storage.LyricsQuery, limit, offset int) ([]*storage.SearchHit, error) {
+	t1 := time.Now()
+	hits, err := s.next.SearchLyrics(ctx, query, limit, offset)
+	s.observe("SearchLyrics", t1, err)
+	return hits, err
+}
+
+func (s *Storage) Suggest(ctx context.Context, kind storage.SuggestKind, prefix string, limit int) ([]*storage.Suggestion, error) {
+	t1 := time.Now()
+	suggestions, err := s.next.Suggest(ctx, kind, prefix, limit)
+	s.observe("Suggest", t1, err)
+	return suggestions, err
+}
+
+func (s *Storage) ListArtists(ctx context.Context, limit, offset int) ([]*storage.Artist, error) {
+	t1 := time.Now()
+	artists, err := s.next.ListArtists(ctx, limit, offset)
+	s.observe("ListArtists", t1, err)
+	return artists, err
+}
+
+func (s *Storage) GetArtist(ctx context.Context, id int) (*storage.Artist, error) {
+	t1 := time.Now()
+	artist, err := s.next.GetArtist(ctx, id)
+	s.observe("GetArtist", t1, err)
+	return artist, err
+}
+
+func (s *Storage) AddArtist(ctx context.Context, artist *storage.Artist) error {
+	t1 := time.Now()
+	err := s.next.AddArtist(ctx, artist)
+	s.observe("AddArtist", t1, err)
+	return err
+}
+
+func (s *Storage) RenameArtist(ctx context.Context, id int, name string) error {
+	t1 := time.Now()
+	err := s.next.RenameArtist(ctx, id, name)
+	s.observe("RenameArtist", t1, err)
+	return err
+}
+
+func (s *Storage) DeleteArtist(ctx context.Context, id int, cascade bool) error {
+	t1 := time.Now()
+	err := s.next.DeleteArtist(ctx, id, cascade)
+	s.observe("DeleteArtist", t1, err)
+	return err
+}
+
+func (s *Storage) MergeArtists(ctx context.Context, targetID int, sourceIDs []int) (int, error) {
+	t1 := time.Now()
+	moved, err := s.next.MergeArtists(ctx, targetID, sourceIDs)
+	s.observe("MergeArtists", t1, err)
+	return moved, err
+}
+
+func (s *Storage) ListAlbums(ctx context.Context, limit, offset int) ([]*storage.Album, error) {
+	t1 := time.Now()
+	albums, err := s.next.ListAlbums(ctx, limit, offset)
+	s.observe("ListAlbums", t1, err)
+	return albums, err
+}
+
+func (s *Storage) GetAlbum(ctx context.Context, id int) (*storage.Album, error) {
+	t1 := time.Now()
+	album, err := s.next.GetAlbum(ctx, id)
+	s.observe("GetAlbum", t1, err)
+	return album, err
+}
+
+func (s *Storage) AddAlbum(ctx context.Context, album *storage.Album) error {
+	t1 := time.Now()
+	err := s.next.AddAlbum(ctx, album)
+	s.observe("AddAlbum", t1, err)
+	return err
+}
+
+func (s *Storage) UpdateAlbum(ctx context.Context, album *storage.Album) error {
+	t1 := time.Now()
+	err := s.next.UpdateAlbum(ctx, album)
+	s.observe("UpdateAlbum", t1, err)
+	return err
+}
+
+func (s *Storage) DeleteAlbum(ctx context.Context, id int) error {
+	t1 := time.Now()
+	err := s.next.DeleteAlbum(ctx, id)
+	s.observe("DeleteAlbum", t1, err)
+	return err
+}
+
+func (s *Storage) TagSong(ctx context.Context, songID int, kind storage.TagKind, names []string) error {
+	t1 := time.Now()
+	err := s.next.TagSong(ctx, songID, kind, names)
+	s.observe("TagSong", t1, err)
+	return err
+}
+
+func (s *Storage) UntagSong(ctx context.Context, songID int, kind storage.TagKind, names []string) error {
+	t1 := time.Now()
+	err := s.next.UntagSong(ctx, songID, kind, names)
+	s.observe("UntagSong", t1, err)
+	return err
+}
+
+func (s *Storage) ListTags(ctx context.Context, kind storage.TagKind, limit, offset int) ([]*storage.TagUsage, error) {
+	t1 := time.Now()
+	usages, err := s.next.ListTags(ctx, kind, limit, offset)
+	s.observe("ListTags", t1, err)
+	return usages, err
+}
+
+func (s *Storage) ListRevisions(ctx context.Context, songID, limit, offset int) ([]*storage.Revision, error) {
+	t1 := time.Now()
+	revisions, err := s.next.ListRevisions(ctx, songID, limit, offset)
+	s.observe("ListRevisions", t1, err)
+	return revisions, err
+}
+
+func (s *Storage) GetRevision(ctx context.Context, songID, number int) (*storage.Revision, error) {
+	t1 := time.Now()
+	rev, err := s.next.GetRevision(ctx, songID, number)
+	s.observe("GetRevision", t1, err)
+	return rev, err
+}
+
+func (s *Storage) RestoreRevision(ctx context.Context, songID, number int) error {
+	t1 := time.Now()
+	err := s.next.RestoreRevision(ctx, songID, number)
+	s.observe("RestoreRevision", t1, err)
+	return err
+}
+
+func (s *Storage) ListTrash(ctx context.Context, limit, offset int) ([]*storage.TrashedSong, error) {
+	t1 := time.Now()
+	songs, err := s.next.ListTrash(ctx, limit, offset)
+	s.observe("ListTrash", t1, err)
+	return songs, err
+}
+
+func (s *Storage) RestoreSong(ctx context.Context, id int) error {
+	t1 := time.Now()
+	err := s.next.RestoreSong(ctx, id)
+	s.observe("RestoreSong", t1, err)
+	return err
+}
+
+func (s *Storage) PurgeTrash(ctx context.Context, before time.Time) (int, error) {
+	t1 := time.Now()
+	purged, err := s.next.PurgeTrash(ctx, before)
+	s.observe("PurgeTrash", t1, err)
+	return purged, err
+}
+
+func (s *Storage) GetSongLyrics(ctx context.Context, artist, title string, limit, offset int) (string, error) {
+	t1 := time.Now()
+	lyrics, err := s.next.GetSongLyrics(ctx, artist, title, limit, offset)
+	s.observe("GetSongLyrics", t1, err)
+	return lyrics, err
+}
+
+func (s *Storage) DeleteSong(ctx context.Context, artist, title string) error {
+	t1 := time.Now()
+	err := s.next.DeleteSong(ctx, artist, title)
+	s.observe("DeleteSong", t1, err)
+	return err
+}
+
+func (s *Storage) UpdateSong(ctx context.Context, artist, title string, upd *storage.SongUpdate) error {
+	t1 := time.Now()
+	err := s.next.UpdateSong(ctx, artist, title, upd)
+	s.observe("UpdateSong", t1, err)
+	return err
+}
+
+func (s *Storage) AddSong(ctx context.Context, song *storage.Song) error {
+	t1 := time.Now()
+	err := s.next.AddSong(ctx, song)
+	s.observe("AddSong", t1, err)
+	return err
+}
+
+func (s *Storage) GetSong(ctx context.Context, artist, title string) (*storage.Song, error) {
+	t1 := time.Now()
+	song, err := s.next.GetSong(ctx, artist, title)
+	s.observe("GetSong", t1, err)
+	return song, err
+}
+
+func (s *Storage) GetSongByID(ctx context.Context, id int) (*storage.Song, error) {
+	t1 := time.Now()
+	song, err := s.next.GetSongByID(ctx, id)
+	s.observe("GetSongByID", t1, err)
+	return song, err
+}
+
+func (s *Storage) GetSongLyricsByID(ctx context.Context, id, limit, offset int) (string, error) {
+	t1 := time.Now()
+	lyrics, err := s.next.GetSongLyricsByID(ctx, id, limit, offset)
+	s.observe("GetSongLyricsByID", t1, err)
+	return lyrics, err
+}
+
+func (s *Storage) DeleteSongByID(ctx context.Context, id int) error {
+	t1 := time.Now()
+	err := s.next.DeleteSongByID(ctx, id)
+	s.observe("DeleteSongByID", t1, err)
+	return err
+}
+
+func (s *Storage) UpdateSongByID(ctx context.Context, id int, upd *storage.SongUpdate) error {
+	t1 := time.Now()
+	err := s.next.UpdateSongByID(ctx, id, upd)
+	s.observe("UpdateSongByID", t1, err)
+	return err
+}
+
+func (s *Storage) Ping(ctx context.Context) error {
+	t1 := time.Now()
+	err := s.next.Ping(ctx)
+	s.observe("Ping", t1, err)
+	return err
+}
+
+func (s *Storage) Close() {
+	s.next.Close()
+}
@@ -0,0 +1,205 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"song-library/internal/storage"
+)
+
+type album struct {
+	id          int
+	artistID    int
+	title       string
+	releaseDate time.Time
+	coverLink   string
+	// tracks hold Number and SongID only, ordered by number.
+	tracks []storage.Track
+}
+
+func (s *Storage) ListAlbums(_ context.Context, limit, offset int) ([]*storage.Album, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := slices.Sorted(maps.Keys(s.albums))
+	if offset >= len(ids) {
+		return nil, nil
+	}
+	ids = ids[offset:]
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	albums := make([]*storage.Album, len(ids))
+	for i, id := range ids {
+		albums[i] = s.toAlbum(s.albums[id])
+		albums[i].Tracks = nil
+	}
+
+	return albums, nil
+}
+
+func (s *Storage) GetAlbum(_ context.Context, id int) (*storage.Album, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	al, ok := s.albums[id]
+	if !ok {
+		return nil, storage.ErrAlbumNotFound
+	}
+
+	return s.toAlbum(al), nil
+}
+
+func (s *Storage) AddAlbum(_ context.Context, a *storage.Album) error {
+	const op = "storage.memory.AddAlbum"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tracks, err := s.checkTracks(a.Tracks)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	a.ID = s.nextAlbumID
+	s.albums[a.ID] = &album{
+		id:          a.ID,
+		artistID:    s.ensureArtist(a.Artist),
+		title:       a.Title,
+		releaseDate: truncateDate(a.ReleaseDate),
+		coverLink:   a.CoverLink,
+		tracks:      tracks,
+	}
+	s.nextAlbumID++
+
+	return nil
+}
+
+func (s *Storage) UpdateAlbum(_ context.Context, a *storage.Album) error {
+	const op = "storage.memory.UpdateAlbum"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	al, ok := s.albums[a.ID]
+	if !ok {
+		return storage.ErrAlbumNotFound
+	}
+
+	tracks, err := s.checkTracks(a.Tracks)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	al.artistID = s.ensureArtist(a.Artist)
+	al.title = a.Title
+	al.releaseDate = truncateDate(a.ReleaseDate)
+	al.coverLink = a.CoverLink
+	al.tracks = tracks
+
+	return nil
+}
+
+func (s *Storage) DeleteAlbum(_ context.Context, id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.albums[id]; !ok {
+		return storage.ErrAlbumNotFound
+	}
+
+	delete(s.albums, id)
+
+	return nil
+}
+
+// checkTracks validates a track listing as the album_tracks constraints
+// do and returns a copy ordered by number.
+func (s *Storage) checkTracks(tracks []storage.Track) ([]storage.Track, error) {
+	if err := storage.CheckTracks(tracks); err != nil {
+		return nil, err
+	}
+
+	res := make([]storage.Track, len(tracks))
+	for i, track := range tracks {
+		if s.indexByID(track.SongID) < 0 {
+			return nil, fmt.Errorf("%w: %d", storage.ErrTrackSongNotFound, track.SongID)
+		}
+		res[i] = storage.Track{Number: track.Number, SongID: track.SongID}
+	}
+	slices.SortFunc(res, func(a, b storage.Track) int { return a.Number - b.Number })
+
+	return res, nil
+}
+
+// removeTracks drops the deleted songs from all track listings, as the
+// ON DELETE CASCADE of album_tracks does.
+func (s *Storage) removeTracks(deleted func(songID int) bool) {
+	for _, al := range s.albums {
+		al.tracks = slices.DeleteFunc(al.tracks, func(t storage.Track) bool { return deleted(t.SongID) })
+	}
+}
+
+func (s *Storage) toAlbum(al *album) *storage.Album {
+	a := &storage.Album{
+		ID:          al.id,
+		Artist:      s.artists[al.artistID],
+		Title:       al.title,
+		ReleaseDate: al.releaseDate,
+		CoverLink:   al.coverLink,
+	}
+	for _, track := range al.tracks {
+		i := s.indexByID(track.SongID)
+		if i < 0 {
+			continue // The song is in the trash.
+		}
+		track.Artist, track.Title = s.artists[s.songs[i].artistID], s.songs[i].title
+		a.Tracks = append(a.Tracks, track)
+	}
+	a.TrackCount = len(a.Tracks)
+	return a
+}
+
+// songAlbums returns the albums a song appears on, by album ID.
+func (s *Storage) songAlbums(songID int) []storage.SongAlbum {
+	var albums []storage.SongAlbum
+	for _, id := range slices.Sorted(maps.Keys(s.albums)) {
+		al := s.albums[id]
+		for _, track := range al.tracks {
+			if track.SongID == songID {
+				albums = append(albums, storage.SongAlbum{ID: id, Title: al.title, ReleaseDate: al.releaseDate, Track: track.Number})
+			}
+		}
+	}
+	return albums
+}
+
+// releaseDate returns the release date of sg or, if it has none, that of
+// its earliest dated album.
+func (s *Storage) releaseDate(sg *song) time.Time {
+	if !sg.releaseDate.IsZero() {
+		return sg.releaseDate
+	}
+
+	var earliest time.Time
+	for _, al := range s.songAlbums(sg.id) {
+		if !al.ReleaseDate.IsZero() && (earliest.IsZero() || al.ReleaseDate.Before(earliest)) {
+			earliest = al.ReleaseDate
+		}
+	}
+	return earliest
+}
+
+// onAlbum reports whether sg is on an album whose title matches the ILIKE pattern.
+func (s *Storage) onAlbum(sg *song, pattern string) bool {
+	for _, al := range s.songAlbums(sg.id) {
+		if ilike(al.Title, pattern) {
+			return true
+		}
+	}
+	return false
+}
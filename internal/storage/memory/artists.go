@@ -0,0 +1,222 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"song-library/internal/storage"
+)
+
+func (s *Storage) ListArtists(_ context.Context, limit, offset int) ([]*storage.Artist, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := slices.Sorted(maps.Keys(s.artists))
+	if offset >= len(ids) {
+		return nil, nil
+	}
+	ids = ids[offset:]
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	artists := make([]*storage.Artist, len(ids))
+	for i, id := range ids {
+		artists[i] = s.toArtist(id)
+	}
+
+	return artists, nil
+}
+
+func (s *Storage) GetArtist(_ context.Context, id int) (*storage.Artist, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.artists[id]; !ok {
+		return nil, storage.ErrArtistNotFound
+	}
+
+	return s.toArtist(id), nil
+}
+
+func (s *Storage) AddArtist(_ context.Context, artist *storage.Artist) error {
+	const op = "storage.memory.AddArtist"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.opts.NormalizeArtistNames {
+		artist.Name = storage.NormalizeArtistName(artist.Name)
+	}
+	if s.artistID(artist.Name) != 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrArtistExists)
+	}
+
+	artist.ID = s.ensureArtist(artist.Name)
+
+	return nil
+}
+
+func (s *Storage) RenameArtist(_ context.Context, id int, name string) error {
+	const op = "storage.memory.RenameArtist"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.artists[id]; !ok {
+		return storage.ErrArtistNotFound
+	}
+	if s.opts.NormalizeArtistNames {
+		name = storage.NormalizeArtistName(name)
+	}
+	if other := s.artistID(name); other != 0 && other != id {
+		return fmt.Errorf("%s: %w", op, storage.ErrArtistExists)
+	}
+
+	s.artists[id] = name
+
+	return nil
+}
+
+func (s *Storage) DeleteArtist(ctx context.Context, id int, cascade bool) error {
+	const op = "storage.memory.DeleteArtist"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.artists[id]; !ok {
+		return storage.ErrArtistNotFound
+	}
+
+	hasSongs := func(sg *song) bool { return sg.artistID == id }
+	if !cascade && (slices.ContainsFunc(s.songs, hasSongs) || slices.ContainsFunc(s.trash, hasSongs)) {
+		return fmt.Errorf("%s: %w", op, storage.ErrArtistHasSongs)
+	}
+
+	deleted := make(map[int]bool)
+	for _, sg := range s.songs {
+		if hasSongs(sg) {
+			deleted[sg.id] = true
+			s.record(ctx, sg, storage.ActionDelete)
+		}
+	}
+	for _, sg := range s.trash {
+		if hasSongs(sg) {
+			deleted[sg.id] = true
+		}
+	}
+	s.songs = slices.DeleteFunc(s.songs, hasSongs)
+	s.trash = slices.DeleteFunc(s.trash, hasSongs)
+	for _, sg := range slices.Concat(s.songs, s.trash) {
+		sg.credits = slices.DeleteFunc(sg.credits, func(c credit) bool { return c.artistID == id })
+	}
+	s.removeTracks(func(songID int) bool { return deleted[songID] })
+	maps.DeleteFunc(s.albums, func(_ int, al *album) bool { return al.artistID == id })
+	delete(s.artists, id)
+
+	return nil
+}
+
+func (s *Storage) MergeArtists(ctx context.Context, targetID int, sourceIDs []int) (int, error) {
+	const op = "storage.memory.MergeArtists"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	merged := map[int]bool{targetID: true}
+	for _, id := range sourceIDs {
+		merged[id] = true
+	}
+	for id := range merged {
+		if _, ok := s.artists[id]; !ok {
+			return 0, storage.ErrArtistNotFound
+		}
+	}
+
+	byTitle := make(map[string][]int)
+	for _, sg := range s.songs {
+		if merged[sg.artistID] {
+			byTitle[sg.title] = append(byTitle[sg.title], sg.id)
+		}
+	}
+	var conflicts []storage.MergeConflict
+	for title, ids := range byTitle {
+		if len(ids) > 1 {
+			slices.Sort(ids)
+			conflicts = append(conflicts, storage.MergeConflict{Title: title, SongIDs: ids})
+		}
+	}
+	if len(conflicts) > 0 {
+		slices.SortFunc(conflicts, func(a, b storage.MergeConflict) int { return strings.Compare(a.Title, b.Title) })
+		return 0, fmt.Errorf("%s: %w", op, &storage.MergeConflictError{Conflicts: conflicts})
+	}
+
+	var moved []*song
+	for _, sg := range s.songs {
+		if sg.artistID != targetID && merged[sg.artistID] {
+			sg.artistID = targetID
+			moved = append(moved, sg)
+		}
+	}
+	for _, sg := range s.trash {
+		if merged[sg.artistID] {
+			sg.artistID = targetID
+		}
+	}
+	for _, sg := range slices.Concat(s.songs, s.trash) {
+		for i := range sg.credits {
+			if merged[sg.credits[i].artistID] {
+				sg.credits[i].artistID = targetID
+			}
+		}
+		sg.credits = uniqueCredits(sg.credits)
+	}
+	for _, al := range s.albums {
+		if merged[al.artistID] {
+			al.artistID = targetID
+		}
+	}
+	for _, id := range sourceIDs {
+		delete(s.artists, id)
+	}
+	for _, sg := range moved {
+		s.record(ctx, sg, storage.ActionUpdate)
+	}
+
+	return len(moved), nil
+}
+
+func (s *Storage) toArtist(id int) *storage.Artist {
+	artist := &storage.Artist{ID: id, Name: s.artists[id]}
+	for _, sg := range s.songs {
+		if sg.artistID == id {
+			artist.SongCount++
+		}
+	}
+	return artist
+}
+
+// artistID returns the ID of the artist with this name, or 0. With
+// normalized names, case and whitespace are ignored and the oldest
+// matching artist wins, as in the Postgres backend.
+func (s *Storage) artistID(name string) int {
+	if !s.opts.NormalizeArtistNames {
+		for id, artist := range s.artists {
+			if artist == name {
+				return id
+			}
+		}
+		return 0
+	}
+
+	key := strings.ToLower(storage.NormalizeArtistName(name))
+	for _, id := range slices.Sorted(maps.Keys(s.artists)) {
+		if strings.ToLower(storage.NormalizeArtistName(s.artists[id])) == key {
+			return id
+		}
+	}
+	return 0
+}
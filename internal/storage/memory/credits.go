@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"cmp"
+	"slices"
+	"song-library/internal/storage"
+	"strings"
+)
+
+// credit is a credit of a song other than its primary artist.
+type credit struct {
+	artistID int
+	role     storage.ArtistRole
+}
+
+// ensureCredits resolves the artists of credits, creating them as needed,
+// and drops repeated credits as the song_artists primary key does.
+func (s *Storage) ensureCredits(credits []storage.Credit) []credit {
+	res := make([]credit, 0, len(credits))
+	for _, c := range credits {
+		res = append(res, credit{artistID: s.ensureArtist(c.Artist), role: c.Role})
+	}
+	return uniqueCredits(res)
+}
+
+func uniqueCredits(credits []credit) []credit {
+	var res []credit
+	for _, c := range credits {
+		if !slices.Contains(res, c) {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// songCredits lists every artist of sg, the primary artist first, then
+// by role and artist name, like the Postgres backend.
+func (s *Storage) songCredits(sg *song) []storage.Credit {
+	credits := make([]storage.Credit, 0, len(sg.credits)+1)
+	credits = append(credits, storage.Credit{ArtistID: sg.artistID, Artist: s.artists[sg.artistID], Role: storage.RolePrimary})
+	for _, c := range sg.credits {
+		credits = append(credits, storage.Credit{ArtistID: c.artistID, Artist: s.artists[c.artistID], Role: c.role})
+	}
+
+	slices.SortStableFunc(credits, func(a, b storage.Credit) int {
+		if c := cmp.Compare(storage.RoleRank(a.Role), storage.RoleRank(b.Role)); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.Artist, b.Artist); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ArtistID, b.ArtistID)
+	})
+
+	return credits
+}
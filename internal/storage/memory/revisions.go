@@ -0,0 +1,139 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"song-library/internal/storage"
+	"time"
+)
+
+// record appends the current state of sg to its revisions.
+func (s *Storage) record(ctx context.Context, sg *song, action storage.RevisionAction) {
+	credits := s.songCredits(sg)[1:]
+	for i := range credits {
+		credits[i].ArtistID = 0
+	}
+
+	revisions := s.revisions[sg.id]
+	s.revisions[sg.id] = append(revisions, &storage.Revision{
+		SongID:    sg.id,
+		Number:    len(revisions) + 1,
+		Action:    action,
+		Author:    storage.Author(ctx),
+		CreatedAt: time.Now().UTC(),
+		Song: storage.Song{
+			Artist:      s.artists[sg.artistID],
+			Title:       sg.title,
+			ReleaseDate: sg.releaseDate,
+			Lyrics:      sg.lyrics,
+			Link:        sg.link,
+			Genres:      slices.Clone(sg.genres),
+			Tags:        slices.Clone(sg.tags),
+			Credits:     credits,
+		},
+	})
+}
+
+func (s *Storage) ListRevisions(_ context.Context, songID, limit, offset int) ([]*storage.Revision, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	revisions := s.revisions[songID]
+	if len(revisions) == 0 {
+		return nil, storage.ErrSongNotFound
+	}
+
+	revisions = slices.Clone(revisions)
+	slices.Reverse(revisions)
+	if offset >= len(revisions) {
+		return nil, nil
+	}
+	revisions = revisions[offset:]
+	if len(revisions) > limit {
+		revisions = revisions[:limit]
+	}
+
+	res := make([]*storage.Revision, len(revisions))
+	for i, rev := range revisions {
+		res[i] = cloneRevision(rev)
+	}
+
+	return res, nil
+}
+
+func (s *Storage) GetRevision(_ context.Context, songID, number int) (*storage.Revision, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	revisions := s.revisions[songID]
+	if number < 1 || number > len(revisions) {
+		return nil, storage.ErrRevisionNotFound
+	}
+
+	return cloneRevision(revisions[number-1]), nil
+}
+
+func (s *Storage) RestoreRevision(ctx context.Context, songID, number int) error {
+	const op = "storage.memory.RestoreRevision"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	revisions := s.revisions[songID]
+	if number < 1 || number > len(revisions) {
+		return storage.ErrRevisionNotFound
+	}
+	snapshot := cloneRevision(revisions[number-1]).Song
+
+	if i := s.indexByID(songID); i >= 0 {
+		err := s.applyUpdate(s.songs[i], &storage.SongUpdate{
+			Artist:      snapshot.Artist,
+			Title:       snapshot.Title,
+			ReleaseDate: &snapshot.ReleaseDate,
+			Lyrics:      &snapshot.Lyrics,
+			Link:        &snapshot.Link,
+			Genres:      &snapshot.Genres,
+			Tags:        &snapshot.Tags,
+			Credits:     &snapshot.Credits,
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		s.record(ctx, s.songs[i], storage.ActionRestore)
+		return nil
+	}
+
+	if i := s.indexTrash(songID); i >= 0 {
+		err := s.applyUpdate(s.trash[i], &storage.SongUpdate{
+			Artist:      snapshot.Artist,
+			Title:       snapshot.Title,
+			ReleaseDate: &snapshot.ReleaseDate,
+			Lyrics:      &snapshot.Lyrics,
+			Link:        &snapshot.Link,
+			Genres:      &snapshot.Genres,
+			Tags:        &snapshot.Tags,
+			Credits:     &snapshot.Credits,
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		s.record(ctx, s.untrash(i), storage.ActionRestore)
+		return nil
+	}
+
+	if err := s.insertSong(&snapshot, songID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	s.record(ctx, s.songs[len(s.songs)-1], storage.ActionRestore)
+
+	return nil
+}
+
+func cloneRevision(rev *storage.Revision) *storage.Revision {
+	res := *rev
+	res.Song.Genres = slices.Clone(rev.Song.Genres)
+	res.Song.Tags = slices.Clone(rev.Song.Tags)
+	res.Song.Credits = slices.Clone(rev.Song.Credits)
+	return &res
+}
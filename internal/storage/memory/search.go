@@ -0,0 +1,214 @@
+package memory
+
+import (
+	"context"
+	"html"
+	"slices"
+	"strings"
+	"unicode"
+
+	"song-library/internal/storage"
+)
+
+// searchTerm is a word or phrase of a web search query.
+type searchTerm struct {
+	words  []string
+	negate bool
+}
+
+// SearchLyrics approximates the Postgres search: it understands the same
+// query syntax but does not stem words, and ranks by match frequency.
+func (s *Storage) SearchLyrics(_ context.Context, query storage.LyricsQuery, limit, offset int) ([]*storage.SearchHit, error) {
+	alternatives := parseWebSearch(query.Text)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var hits []*storage.SearchHit
+	for _, sg := range s.songs {
+		text := strings.ReplaceAll(sg.lyrics, `\n`, "\n")
+		words := splitWords(text)
+
+		var matched []searchTerm
+		for _, terms := range alternatives {
+			if matchTerms(words, terms) {
+				matched = append(matched, terms...)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+
+		song := s.toSong(sg)
+		song.Lyrics = ""
+		hits = append(hits, &storage.SearchHit{
+			Song:     *song,
+			Rank:     rank(words, matched),
+			Headline: headline(text, matched),
+		})
+	}
+
+	slices.SortStableFunc(hits, func(a, b *storage.SearchHit) int {
+		switch {
+		case a.Rank > b.Rank:
+			return -1
+		case a.Rank < b.Rank:
+			return 1
+		}
+		return a.Song.ID - b.Song.ID
+	})
+
+	if offset >= len(hits) {
+		return nil, nil
+	}
+	hits = hits[offset:]
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	return hits, nil
+}
+
+// parseWebSearch splits a query into alternatives separated by "or",
+// each a list of terms that must all hold.
+func parseWebSearch(text string) [][]searchTerm {
+	var (
+		alternatives [][]searchTerm
+		current      []searchTerm
+	)
+	for len(text) > 0 {
+		text = strings.TrimLeftFunc(text, unicode.IsSpace)
+		if text == "" {
+			break
+		}
+
+		var term searchTerm
+		if rest, ok := strings.CutPrefix(text, "-"); ok {
+			term.negate = true
+			text = rest
+		}
+
+		var token string
+		if rest, ok := strings.CutPrefix(text, `"`); ok {
+			token, text, _ = strings.Cut(rest, `"`)
+		} else {
+			end := strings.IndexFunc(text, unicode.IsSpace)
+			if end < 0 {
+				end = len(text)
+			}
+			token, text = text[:end], text[end:]
+			if !term.negate && strings.EqualFold(token, "or") {
+				if len(current) > 0 {
+					alternatives = append(alternatives, current)
+					current = nil
+				}
+				continue
+			}
+		}
+
+		if term.words = splitWords(token); len(term.words) > 0 {
+			current = append(current, term)
+		}
+	}
+	if len(current) > 0 {
+		alternatives = append(alternatives, current)
+	}
+
+	return alternatives
+}
+
+// matchTerms reports whether words contain every positive term and none
+// of the negated ones. At least one term must be positive.
+func matchTerms(words []string, terms []searchTerm) bool {
+	positive := false
+	for _, term := range terms {
+		if (countPhrase(words, term.words) > 0) == term.negate {
+			return false
+		}
+		positive = positive || !term.negate
+	}
+	return positive
+}
+
+func countPhrase(words, phrase []string) int {
+	count := 0
+	for i := 0; i+len(phrase) <= len(words); i++ {
+		if slices.Equal(words[i:i+len(phrase)], phrase) {
+			count++
+		}
+	}
+	return count
+}
+
+func rank(words []string, terms []searchTerm) float64 {
+	count := 0
+	for _, term := range terms {
+		if !term.negate {
+			count += countPhrase(words, term.words)
+		}
+	}
+	return float64(count) / float64(len(words))
+}
+
+// headline returns up to two lines of text that contain matched words,
+// HTML-escaped, with those words highlighted.
+func headline(text string, terms []searchTerm) string {
+	highlight := make(map[string]bool)
+	for _, term := range terms {
+		if term.negate {
+			continue
+		}
+		for _, word := range term.words {
+			highlight[word] = true
+		}
+	}
+
+	var fragments []string
+	for _, line := range strings.Split(text, "\n") {
+		if len(fragments) == 2 {
+			break
+		}
+
+		var (
+			b     strings.Builder
+			found bool
+		)
+		for len(line) > 0 {
+			start := strings.IndexFunc(line, isWordRune)
+			if start < 0 {
+				b.WriteString(html.EscapeString(line))
+				break
+			}
+			end := strings.IndexFunc(line[start:], func(r rune) bool { return !isWordRune(r) })
+			if end < 0 {
+				end = len(line)
+			} else {
+				end += start
+			}
+
+			b.WriteString(html.EscapeString(line[:start]))
+			word := line[start:end]
+			if highlight[strings.ToLower(word)] {
+				found = true
+				b.WriteString(storage.HighlightStart + html.EscapeString(word) + storage.HighlightStop)
+			} else {
+				b.WriteString(html.EscapeString(word))
+			}
+			line = line[end:]
+		}
+
+		if found {
+			fragments = append(fragments, strings.TrimSpace(b.String()))
+		}
+	}
+
+	return strings.Join(fragments, " ... ")
+}
+
+func splitWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !isWordRune(r) })
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
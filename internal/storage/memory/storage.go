@@ -0,0 +1,621 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"song-library/internal/storage"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Storage keeps songs in process memory. It mirrors the semantics of
+// postgres.Storage and is meant for tests and demos.
+type Storage struct {
+	mu      sync.RWMutex
+	artists map[int]string
+	songs   []*song
+	// trash holds the deleted songs, which no other read sees.
+	trash        []*song
+	albums       map[int]*album
+	revisions    map[int][]*storage.Revision
+	nextArtistID int
+	nextSongID   int
+	nextAlbumID  int
+	opts         storage.Options
+}
+
+type song struct {
+	id          int
+	artistID    int
+	title       string
+	releaseDate time.Time
+	lyrics      string
+	link        string
+	genres      []string
+	tags        []string
+	// credits holds the credits other than the primary artist.
+	credits []credit
+	// deletedAt is set while the song is in the trash.
+	deletedAt time.Time
+}
+
+func New(opts storage.Options) *Storage {
+	return &Storage{
+		artists:      make(map[int]string),
+		albums:       make(map[int]*album),
+		revisions:    make(map[int][]*storage.Revision),
+		nextArtistID: 1,
+		nextSongID:   1,
+		nextAlbumID:  1,
+		opts:         opts,
+	}
+}
+
+// Ping always succeeds.
+func (s *Storage) Ping(context.Context) error { return nil }
+
+// Close is a no-op; it exists to satisfy storage.Storage.
+func (s *Storage) Close() {}
+
+func (s *Storage) GetSongs(_ context.Context, filter *storage.SongFilter, page storage.Page) ([]*storage.Song, error) {
+	conditions := s.filterConditions(filter)
+	order := page.Order()
+	fuzzy := filter != nil && filter.Fuzzy
+
+	var boundary *storage.Song
+	backward := page.Cursor != nil && page.Cursor.Backward
+	if c := page.Cursor; c != nil {
+		boundary = &storage.Song{ID: c.ID, Artist: c.Artist, Title: c.Title, ReleaseDate: c.ReleaseDate}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var matched []*storage.Song
+	for _, sg := range s.songs {
+		if !matchAll(sg, conditions) {
+			continue
+		}
+		song := s.toSong(sg)
+		if fuzzy {
+			song.Score = fuzzyScore(filter, song)
+		}
+		if boundary != nil {
+			c := compareSongs(song, boundary, order)
+			if c == 0 || (c < 0) != backward {
+				continue
+			}
+		}
+		matched = append(matched, song)
+	}
+
+	slices.SortFunc(matched, func(a, b *storage.Song) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return compareSongs(a, b, order)
+	})
+	if backward {
+		slices.Reverse(matched)
+	}
+
+	if page.Offset >= len(matched) {
+		return nil, nil
+	}
+	matched = matched[page.Offset:]
+	if len(matched) > page.Limit {
+		matched = matched[:page.Limit]
+	}
+	if backward {
+		slices.Reverse(matched)
+	}
+
+	return matched, nil
+}
+
+func (s *Storage) CountSongs(_ context.Context, filter *storage.SongFilter) (int, error) {
+	conditions := s.filterConditions(filter)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	count := 0
+	for _, sg := range s.songs {
+		if matchAll(sg, conditions) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (s *Storage) GetSongLyrics(_ context.Context, artist, title string, limit, offset int) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sg := s.findLike(artist, title)
+	if sg == nil {
+		return "", storage.ErrSongNotFound
+	}
+
+	return storage.PaginateVerses(sg.lyrics, limit, offset), nil
+}
+
+func (s *Storage) DeleteSong(ctx context.Context, artist, title string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.indexExact(artist, title)
+	if i < 0 {
+		return storage.ErrSongNotFound
+	}
+
+	s.record(ctx, s.songs[i], storage.ActionDelete)
+	s.trashSong(i)
+
+	return nil
+}
+
+func (s *Storage) UpdateSong(ctx context.Context, artist, title string, upd *storage.SongUpdate) error {
+	const op = "storage.memory.UpdateSong"
+
+	if upd.IsEmpty() {
+		return storage.NothingChanged
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.indexExact(artist, title)
+	if i < 0 {
+		return storage.ErrSongNotFound
+	}
+
+	if err := s.applyUpdate(s.songs[i], upd); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	s.record(ctx, s.songs[i], storage.ActionUpdate)
+
+	return nil
+}
+
+func (s *Storage) UpdateSongByID(ctx context.Context, id int, upd *storage.SongUpdate) error {
+	const op = "storage.memory.UpdateSongByID"
+
+	if upd.IsEmpty() {
+		return storage.NothingChanged
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.indexByID(id)
+	if i < 0 {
+		return storage.ErrSongNotFound
+	}
+
+	if err := s.applyUpdate(s.songs[i], upd); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	s.record(ctx, s.songs[i], storage.ActionUpdate)
+
+	return nil
+}
+
+// applyUpdate copies the set fields of upd into sg. Nothing is
+// changed if the new artist and title collide with another song.
+func (s *Storage) applyUpdate(sg *song, upd *storage.SongUpdate) error {
+	var genres, tags []string
+	var err error
+	if upd.Genres != nil {
+		if genres, err = storage.NormalizeTags(*upd.Genres); err != nil {
+			return err
+		}
+	}
+	if upd.Tags != nil {
+		if tags, err = storage.NormalizeTags(*upd.Tags); err != nil {
+			return err
+		}
+	}
+	if upd.Credits != nil {
+		if err := storage.CheckCredits(*upd.Credits); err != nil {
+			return err
+		}
+	}
+
+	artistID, title := sg.artistID, sg.title
+	if upd.Artist != "" {
+		artistID = s.artistID(upd.Artist)
+	}
+	if upd.Title != "" {
+		title = upd.Title
+	}
+	if i := s.indexSong(artistID, title); i >= 0 && s.songs[i] != sg {
+		return storage.ErrSongExists
+	}
+
+	if upd.Artist != "" {
+		sg.artistID = s.ensureArtist(upd.Artist)
+	}
+	if upd.Title != "" {
+		sg.title = upd.Title
+	}
+	if upd.Lyrics != nil {
+		sg.lyrics = *upd.Lyrics
+	}
+	if upd.Link != nil {
+		sg.link = *upd.Link
+	}
+	if upd.ReleaseDate != nil {
+		sg.releaseDate = truncateDate(*upd.ReleaseDate)
+	}
+	if upd.Genres != nil {
+		sg.genres = genres
+	}
+	if upd.Tags != nil {
+		sg.tags = tags
+	}
+	if upd.Credits != nil {
+		sg.credits = s.ensureCredits(*upd.Credits)
+	}
+
+	return nil
+}
+
+func (s *Storage) AddSong(ctx context.Context, sg *storage.Song) error {
+	const op = "storage.memory.AddSong"
+
+	genres, err := storage.NormalizeTags(sg.Genres)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	tags, err := storage.NormalizeTags(sg.Tags)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := storage.CheckCredits(sg.Credits); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sg.Genres, sg.Tags = genres, tags
+	if err := s.insertSong(sg, s.nextSongID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	s.nextSongID++
+	s.record(ctx, s.songs[len(s.songs)-1], storage.ActionAdd)
+
+	return nil
+}
+
+// insertSong adds sg, with normalized labels and checked credits, under
+// the given ID and sets sg.ID.
+func (s *Storage) insertSong(sg *storage.Song, id int) error {
+	if s.indexSong(s.artistID(sg.Artist), sg.Title) >= 0 {
+		return storage.ErrSongExists
+	}
+
+	artistID := s.ensureArtist(sg.Artist)
+
+	sg.ID = id
+	s.songs = append(s.songs, &song{
+		id:          sg.ID,
+		artistID:    artistID,
+		title:       sg.Title,
+		releaseDate: truncateDate(sg.ReleaseDate),
+		lyrics:      sg.Lyrics,
+		link:        sg.Link,
+		genres:      slices.Clone(sg.Genres),
+		tags:        slices.Clone(sg.Tags),
+		credits:     s.ensureCredits(sg.Credits),
+	})
+
+	return nil
+}
+
+func (s *Storage) GetSong(_ context.Context, artist, title string) (*storage.Song, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sg := s.findLike(artist, title)
+	if sg == nil {
+		return nil, storage.ErrSongNotFound
+	}
+
+	song := s.toSong(sg)
+	song.Albums = s.songAlbums(sg.id)
+
+	return song, nil
+}
+
+func (s *Storage) GetSongByID(_ context.Context, id int) (*storage.Song, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.indexByID(id)
+	if i < 0 {
+		return nil, storage.ErrSongNotFound
+	}
+
+	song := s.toSong(s.songs[i])
+	song.Albums = s.songAlbums(id)
+
+	return song, nil
+}
+
+func (s *Storage) GetSongLyricsByID(_ context.Context, id, limit, offset int) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.indexByID(id)
+	if i < 0 {
+		return "", storage.ErrSongNotFound
+	}
+
+	return storage.PaginateVerses(s.songs[i].lyrics, limit, offset), nil
+}
+
+func (s *Storage) DeleteSongByID(ctx context.Context, id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.indexByID(id)
+	if i < 0 {
+		return storage.ErrSongNotFound
+	}
+
+	s.record(ctx, s.songs[i], storage.ActionDelete)
+	s.trashSong(i)
+
+	return nil
+}
+
+// trashSong moves the song at index i to the trash. It keeps its tracks.
+func (s *Storage) trashSong(i int) {
+	sg := s.songs[i]
+	sg.deletedAt = time.Now().UTC()
+	s.songs = append(s.songs[:i], s.songs[i+1:]...)
+	s.trash = append(s.trash, sg)
+}
+
+func (s *Storage) toSong(sg *song) *storage.Song {
+	return &storage.Song{
+		ID:          sg.id,
+		Artist:      s.artists[sg.artistID],
+		Title:       sg.title,
+		ReleaseDate: s.releaseDate(sg),
+		Lyrics:      sg.lyrics,
+		Link:        sg.link,
+		Genres:      slices.Clone(sg.genres),
+		Tags:        slices.Clone(sg.tags),
+		Credits:     s.songCredits(sg),
+	}
+}
+
+// ensureArtist returns the ID of the artist with this name, creating it if
+// needed. Names match exactly unless artist names are normalized.
+func (s *Storage) ensureArtist(name string) int {
+	if s.opts.NormalizeArtistNames {
+		name = storage.NormalizeArtistName(name)
+	}
+	if id := s.artistID(name); id != 0 {
+		return id
+	}
+
+	id := s.nextArtistID
+	s.nextArtistID++
+	s.artists[id] = name
+
+	return id
+}
+
+// indexExact finds a song the way DELETE/UPDATE do: exact, case-sensitive match.
+func (s *Storage) indexExact(artist, title string) int {
+	for i, sg := range s.songs {
+		if s.artists[sg.artistID] == artist && sg.title == title {
+			return i
+		}
+	}
+	return -1
+}
+
+// indexSong finds the song of the artist with exactly this title, as the
+// unique index on (artist_id, title) outside the trash does.
+func (s *Storage) indexSong(artistID int, title string) int {
+	for i, sg := range s.songs {
+		if sg.artistID == artistID && sg.title == title {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Storage) indexByID(id int) int {
+	for i, sg := range s.songs {
+		if sg.id == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// findLike finds a song the way SELECT does: ILIKE on both artist and title.
+func (s *Storage) findLike(artist, title string) *song {
+	for _, sg := range s.songs {
+		if ilike(s.artists[sg.artistID], artist) && ilike(sg.title, title) {
+			return sg
+		}
+	}
+	return nil
+}
+
+// filterConditions mirrors the WHERE clause built by the Postgres backend.
+func (s *Storage) filterConditions(filter *storage.SongFilter) []func(*song) bool {
+	var conditions []func(*song) bool
+	if filter == nil {
+		return conditions
+	}
+
+	switch {
+	case filter.Fuzzy:
+		if artist := filter.Artist; artist != "" {
+			conditions = append(conditions, func(sg *song) bool {
+				return slices.ContainsFunc(s.songCredits(sg), func(c storage.Credit) bool {
+					return similarity(c.Artist, artist) >= storage.FuzzyThreshold
+				})
+			})
+		}
+		if title := filter.Title; title != "" {
+			conditions = append(conditions, func(sg *song) bool {
+				return similarity(sg.title, title) >= storage.FuzzyThreshold
+			})
+		}
+	default:
+		if filter.Artist != "" {
+			pattern := "%" + storage.EscapeLike(filter.Artist) + "%"
+			conditions = append(conditions, func(sg *song) bool {
+				return slices.ContainsFunc(s.songCredits(sg), func(c storage.Credit) bool { return ilike(c.Artist, pattern) })
+			})
+		}
+		if filter.Title != "" {
+			pattern := "%" + storage.EscapeLike(filter.Title) + "%"
+			conditions = append(conditions, func(sg *song) bool { return ilike(sg.title, pattern) })
+		}
+	}
+	if artistID := filter.ArtistID; artistID != 0 {
+		conditions = append(conditions, func(sg *song) bool { return sg.artistID == artistID })
+	}
+	if filter.Album != "" {
+		pattern := "%" + storage.EscapeLike(filter.Album) + "%"
+		conditions = append(conditions, func(sg *song) bool { return s.onAlbum(sg, pattern) })
+	}
+	if filter.Lyrics != "" {
+		pattern := "%" + storage.EscapeLike(filter.Lyrics) + "%"
+		conditions = append(conditions, func(sg *song) bool { return ilike(sg.lyrics, pattern) })
+	}
+	if from := filter.ReleasedFrom; !from.IsZero() {
+		conditions = append(conditions, func(sg *song) bool { return !s.releaseDate(sg).Before(from) })
+	}
+	if to := filter.ReleasedTo; !to.IsZero() {
+		conditions = append(conditions, func(sg *song) bool {
+			date := s.releaseDate(sg)
+			return !date.IsZero() && !date.After(to)
+		})
+	}
+
+	if genres := filter.Genres; len(genres.Names) > 0 {
+		conditions = append(conditions, func(sg *song) bool { return hasTags(sg.genres, genres) })
+	}
+	if tags := filter.Tags; len(tags.Names) > 0 {
+		conditions = append(conditions, func(sg *song) bool { return hasTags(sg.tags, tags) })
+	}
+
+	switch filter.HasLyrics {
+	case storage.PresencePresent:
+		conditions = append(conditions, func(sg *song) bool { return sg.lyrics != "" })
+	case storage.PresenceAbsent:
+		conditions = append(conditions, func(sg *song) bool { return sg.lyrics == "" })
+	}
+	switch filter.HasLink {
+	case storage.PresencePresent:
+		conditions = append(conditions, func(sg *song) bool { return sg.link != "" })
+	case storage.PresenceAbsent:
+		conditions = append(conditions, func(sg *song) bool { return sg.link == "" })
+	}
+
+	return conditions
+}
+
+// compareSongs orders songs like the ORDER BY of the Postgres backend.
+// Strings compare byte-wise, as under the "C" collation.
+func compareSongs(a, b *storage.Song, order []storage.SortField) int {
+	for _, field := range order {
+		var c int
+		switch field.Key {
+		case storage.SortID:
+			c = cmp.Compare(a.ID, b.ID)
+		case storage.SortArtist:
+			c = strings.Compare(a.Artist, b.Artist)
+		case storage.SortTitle:
+			c = strings.Compare(a.Title, b.Title)
+		case storage.SortReleaseDate:
+			c = a.ReleaseDate.Compare(b.ReleaseDate)
+		}
+		if field.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// fuzzyScore is the mean similarity of the artist and title terms that are
+// set, where the artist term is that of the best-matching credited artist.
+func fuzzyScore(filter *storage.SongFilter, song *storage.Song) float64 {
+	var sum, n float64
+	if filter.Artist != "" {
+		var best float64
+		for _, c := range song.Credits {
+			best = max(best, similarity(c.Artist, filter.Artist))
+		}
+		sum += best
+		n++
+	}
+	if filter.Title != "" {
+		sum += similarity(song.Title, filter.Title)
+		n++
+	}
+	if n == 0 {
+		return 0
+	}
+	return sum / n
+}
+
+func matchAll(sg *song, conditions []func(*song) bool) bool {
+	for _, cond := range conditions {
+		if !cond(sg) {
+			return false
+		}
+	}
+	return true
+}
+
+// ilike reports whether value matches the Postgres ILIKE pattern:
+// '%' matches any sequence, '_' any single character and '\' escapes.
+func ilike(value, pattern string) bool {
+	var b strings.Builder
+	b.WriteString("(?is)^")
+
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			b.WriteString(".*")
+		case r == '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+
+	return regexp.MustCompile(b.String()).MatchString(value)
+}
+
+// truncateDate drops the time of day, as the DATE column does.
+func truncateDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
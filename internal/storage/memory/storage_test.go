@@ -0,0 +1,15 @@
+package memory_test
+
+import (
+	"testing"
+
+	"song-library/internal/storage"
+	"song-library/internal/storage/memory"
+	"song-library/internal/storage/storagetest"
+)
+
+func TestStorage(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T, opts storage.Options) storage.Storage {
+		return memory.New(opts)
+	})
+}
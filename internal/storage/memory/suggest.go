@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"song-library/internal/storage"
+)
+
+func (s *Storage) Suggest(_ context.Context, kind storage.SuggestKind, prefix string, limit int) ([]*storage.Suggestion, error) {
+	const op = "storage.memory.Suggest"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var candidates []*storage.Suggestion
+	switch kind {
+	case storage.SuggestArtist:
+		for id, name := range s.artists {
+			candidates = append(candidates, &storage.Suggestion{ID: id, Name: name})
+		}
+	case storage.SuggestSong:
+		for _, sg := range s.songs {
+			candidates = append(candidates, &storage.Suggestion{ID: sg.id, Name: sg.title, Artist: s.artists[sg.artistID]})
+		}
+	default:
+		return nil, fmt.Errorf("%s: %w: %q", op, storage.ErrInvalidSuggestKind, kind)
+	}
+
+	prefix = strings.ToLower(prefix)
+	rank := func(sg *storage.Suggestion) int {
+		switch name := strings.ToLower(sg.Name); {
+		case strings.HasPrefix(name, prefix):
+			return 0
+		case strings.Contains(name, prefix):
+			return 1
+		default:
+			return -1
+		}
+	}
+
+	candidates = slices.DeleteFunc(candidates, func(sg *storage.Suggestion) bool { return rank(sg) < 0 })
+	slices.SortFunc(candidates, func(a, b *storage.Suggestion) int {
+		return cmp.Or(
+			cmp.Compare(rank(a), rank(b)),
+			strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)),
+			cmp.Compare(a.ID, b.ID),
+		)
+	})
+
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	return candidates, nil
+}
@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"song-library/internal/storage"
+)
+
+func (s *Storage) TagSong(ctx context.Context, songID int, kind storage.TagKind, names []string) error {
+	const op = "storage.memory.TagSong"
+
+	return s.changeTags(ctx, op, songID, kind, names, func(current, names []string) []string {
+		merged := append(slices.Clone(current), names...)
+		slices.Sort(merged)
+		return slices.Compact(merged)
+	})
+}
+
+func (s *Storage) UntagSong(ctx context.Context, songID int, kind storage.TagKind, names []string) error {
+	const op = "storage.memory.UntagSong"
+
+	return s.changeTags(ctx, op, songID, kind, names, func(current, names []string) []string {
+		return slices.DeleteFunc(slices.Clone(current), func(name string) bool { return slices.Contains(names, name) })
+	})
+}
+
+func (s *Storage) changeTags(ctx context.Context, op string, songID int, kind storage.TagKind, names []string, change func(current, names []string) []string) error {
+	names, err := storage.NormalizeTags(names)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.indexByID(songID)
+	if i < 0 {
+		return storage.ErrSongNotFound
+	}
+
+	labels, err := s.songLabels(s.songs[i], kind)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	*labels = change(*labels, names)
+	s.record(ctx, s.songs[i], storage.ActionUpdate)
+
+	return nil
+}
+
+func (s *Storage) ListTags(_ context.Context, kind storage.TagKind, limit, offset int) ([]*storage.TagUsage, error) {
+	const op = "storage.memory.ListTags"
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, sg := range s.songs {
+		labels, err := s.songLabels(sg, kind)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		for _, name := range *labels {
+			counts[name]++
+		}
+	}
+
+	usages := make([]*storage.TagUsage, 0, len(counts))
+	for _, name := range slices.Sorted(maps.Keys(counts)) {
+		usages = append(usages, &storage.TagUsage{Name: name, SongCount: counts[name]})
+	}
+	slices.SortStableFunc(usages, func(a, b *storage.TagUsage) int { return cmp.Compare(b.SongCount, a.SongCount) })
+
+	if offset >= len(usages) {
+		return nil, nil
+	}
+	usages = usages[offset:]
+	if len(usages) > limit {
+		usages = usages[:limit]
+	}
+
+	return usages, nil
+}
+
+// songLabels returns the genres or tags of sg.
+func (s *Storage) songLabels(sg *song, kind storage.TagKind) (*[]string, error) {
+	switch kind {
+	case storage.KindGenre:
+		return &sg.genres, nil
+	case storage.KindTag:
+		return &sg.tags, nil
+	default:
+		return nil, fmt.Errorf("unknown tag kind %q", kind)
+	}
+}
+
+// hasTags reports whether the sorted labels match filter.
+func hasTags(labels []string, filter storage.TagFilter) bool {
+	for _, name := range filter.Names {
+		_, found := slices.BinarySearch(labels, name)
+		if found != filter.All {
+			return !filter.All
+		}
+	}
+	return filter.All
+}
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"song-library/internal/storage"
+	"time"
+)
+
+func (s *Storage) ListTrash(_ context.Context, limit, offset int) ([]*storage.TrashedSong, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	trash := slices.SortedFunc(slices.Values(s.trash), func(a, b *song) int {
+		if c := b.deletedAt.Compare(a.deletedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.id, a.id)
+	})
+	if offset >= len(trash) {
+		return nil, nil
+	}
+	trash = trash[offset:]
+	if len(trash) > limit {
+		trash = trash[:limit]
+	}
+
+	songs := make([]*storage.TrashedSong, len(trash))
+	for i, sg := range trash {
+		songs[i] = &storage.TrashedSong{ID: sg.id, Artist: s.artists[sg.artistID], Title: sg.title, DeletedAt: sg.deletedAt}
+	}
+
+	return songs, nil
+}
+
+func (s *Storage) RestoreSong(ctx context.Context, id int) error {
+	const op = "storage.memory.RestoreSong"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.indexTrash(id)
+	if i < 0 {
+		return storage.ErrSongNotFound
+	}
+	if sg := s.trash[i]; s.indexSong(sg.artistID, sg.title) >= 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrSongExists)
+	}
+
+	s.record(ctx, s.untrash(i), storage.ActionRestore)
+
+	return nil
+}
+
+func (s *Storage) PurgeTrash(_ context.Context, before time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	purged := make(map[int]bool)
+	s.trash = slices.DeleteFunc(s.trash, func(sg *song) bool {
+		if sg.deletedAt.Before(before) {
+			purged[sg.id] = true
+		}
+		return purged[sg.id]
+	})
+	s.removeTracks(func(songID int) bool { return purged[songID] })
+
+	return len(purged), nil
+}
+
+// untrash moves the song at index i of the trash back among the songs and
+// returns it. The caller checks that its title is free.
+func (s *Storage) untrash(i int) *song {
+	sg := s.trash[i]
+	sg.deletedAt = time.Time{}
+	s.trash = append(s.trash[:i], s.trash[i+1:]...)
+	s.songs = append(s.songs, sg)
+	return sg
+}
+
+func (s *Storage) indexTrash(id int) int {
+	for i, sg := range s.trash {
+		if sg.id == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidSort = errors.New("invalid sort")
+
+// SortKey names a column GetSongs can order by.
+type SortKey string
+
+const (
+	SortID          SortKey = "id"
+	SortArtist      SortKey = "artist"
+	SortTitle       SortKey = "title"
+	SortReleaseDate SortKey = "release_date"
+)
+
+var sortKeys = map[SortKey]bool{
+	SortID:          true,
+	SortArtist:      true,
+	SortTitle:       true,
+	SortReleaseDate: true,
+}
+
+type SortField struct {
+	Key  SortKey
+	Desc bool
+}
+
+// Page selects which slice of the GetSongs result is returned. Rows are
+// ordered by Sort and then by song ID, so pages are stable.
+type Page struct {
+	Limit  int
+	Offset int
+	Sort   []SortField
+	// Cursor, if set, must have been built for the same Sort. Offset is
+	// then counted from the cursor. Fuzzy filters do not support cursors,
+	// as their order depends on the search terms.
+	Cursor *Cursor
+}
+
+// Order returns the full ordering of the page: Sort up to and including
+// the ID key, with an ascending ID tiebreaker if Sort has none.
+func (p Page) Order() []SortField {
+	order := make([]SortField, 0, len(p.Sort)+1)
+	for _, field := range p.Sort {
+		order = append(order, field)
+		if field.Key == SortID {
+			return order
+		}
+	}
+	return append(order, SortField{Key: SortID})
+}
+
+// ParseSort parses a comma-separated list of sort keys such as
+// "-release_date,artist". A leading '-' sorts that key in descending order.
+func ParseSort(value string) ([]SortField, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	var (
+		fields []SortField
+		seen   = make(map[SortKey]bool)
+	)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+
+		var field SortField
+		if rest, ok := strings.CutPrefix(part, "-"); ok {
+			field.Desc = true
+			part = rest
+		} else {
+			part = strings.TrimPrefix(part, "+")
+		}
+		field.Key = SortKey(part)
+
+		if !sortKeys[field.Key] {
+			return nil, fmt.Errorf("%w: unknown key %q", ErrInvalidSort, part)
+		}
+		if seen[field.Key] {
+			return nil, fmt.Errorf("%w: duplicate key %q", ErrInvalidSort, part)
+		}
+		seen[field.Key] = true
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,228 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"song-library/internal/storage"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const selectAlbums = `
+	SELECT al.album_id, a.artist_name, al.title, al.release_date, al.cover_link,
+		(SELECT COUNT(*) FROM album_tracks t
+		JOIN songs s ON s.song_id = t.song_id
+		WHERE t.album_id = al.album_id AND s.deleted_at IS NULL)
+	FROM albums al
+	JOIN artists a ON al.artist_id = a.artist_id
+	`
+
+// ListAlbums returns albums ordered by ID, with their track counts but
+// without tracks.
+func (s *Storage) ListAlbums(ctx context.Context, limit, offset int) ([]*storage.Album, error) {
+	const op = "storage.postgres.ListAlbums"
+
+	query := selectAlbums + `
+		ORDER BY al.album_id
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := s.db.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var albums []*storage.Album
+	for rows.Next() {
+		var album storage.Album
+		if err := rows.Scan(&album.ID, &album.Artist, &album.Title, &album.ReleaseDate, &album.CoverLink, &album.TrackCount); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		albums = append(albums, &album)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return albums, nil
+}
+
+// GetAlbum returns an album with its tracks.
+func (s *Storage) GetAlbum(ctx context.Context, id int) (*storage.Album, error) {
+	const op = "storage.postgres.GetAlbum"
+
+	var album storage.Album
+	err := s.db.QueryRow(ctx, selectAlbums+" WHERE al.album_id = $1", id).
+		Scan(&album.ID, &album.Artist, &album.Title, &album.ReleaseDate, &album.CoverLink, &album.TrackCount)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrAlbumNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.db.Query(ctx, `
+		SELECT t.track_number, s.song_id, a.artist_name, s.title
+		FROM album_tracks t
+		JOIN songs s ON s.song_id = t.song_id
+		JOIN artists a ON s.artist_id = a.artist_id
+		WHERE t.album_id = $1 AND s.deleted_at IS NULL
+		ORDER BY t.track_number`, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	album.Tracks, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (storage.Track, error) {
+		var track storage.Track
+		err := row.Scan(&track.Number, &track.SongID, &track.Artist, &track.Title)
+		return track, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &album, nil
+}
+
+// AddAlbum creates an album with its tracks and sets album.ID. The artist
+// is created if needed.
+func (s *Storage) AddAlbum(ctx context.Context, album *storage.Album) error {
+	const op = "storage.postgres.AddAlbum"
+
+	if err := storage.CheckTracks(album.Tracks); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	artistID, err := s.ensureArtist(ctx, tx, album.Artist)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	query := `INSERT INTO albums(artist_id, title, release_date, cover_link) VALUES ($1,$2,$3,$4) RETURNING album_id`
+
+	err = tx.QueryRow(ctx, query, artistID, album.Title, album.ReleaseDate, album.CoverLink).Scan(&album.ID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := insertTracks(ctx, tx, album.ID, album.Tracks); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// UpdateAlbum replaces the album with ID album.ID, track listing included.
+// Tracks of songs in the trash are dropped with the old listing.
+func (s *Storage) UpdateAlbum(ctx context.Context, album *storage.Album) error {
+	const op = "storage.postgres.UpdateAlbum"
+
+	if err := storage.CheckTracks(album.Tracks); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var id int
+	err = tx.QueryRow(ctx, "SELECT album_id FROM albums WHERE album_id = $1 FOR UPDATE", album.ID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storage.ErrAlbumNotFound
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	artistID, err := s.ensureArtist(ctx, tx, album.Artist)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE albums SET artist_id = $1, title = $2, release_date = $3, cover_link = $4
+		WHERE album_id = $5`, artistID, album.Title, album.ReleaseDate, album.CoverLink, album.ID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := tx.Exec(ctx, "DELETE FROM album_tracks WHERE album_id = $1", album.ID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := insertTracks(ctx, tx, album.ID, album.Tracks); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteAlbum removes an album and its track listing; the songs stay.
+func (s *Storage) DeleteAlbum(ctx context.Context, id int) error {
+	const op = "storage.postgres.DeleteAlbum"
+
+	res, err := s.db.Exec(ctx, "DELETE FROM albums WHERE album_id = $1", id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return storage.ErrAlbumNotFound
+	}
+
+	return nil
+}
+
+// insertTracks adds the tracks to the album. Songs in the trash cannot be
+// tracks.
+func insertTracks(ctx context.Context, tx pgx.Tx, albumID int, tracks []storage.Track) error {
+	for _, track := range tracks {
+		res, err := tx.Exec(ctx, `
+			INSERT INTO album_tracks(album_id, song_id, track_number)
+			SELECT $1, song_id, $3 FROM songs WHERE song_id = $2 AND deleted_at IS NULL`,
+			albumID, track.SongID, track.Number)
+		if err != nil {
+			return err
+		}
+		if res.RowsAffected() == 0 {
+			return fmt.Errorf("%w: %d", storage.ErrTrackSongNotFound, track.SongID)
+		}
+	}
+	return nil
+}
+
+// songAlbums returns the albums a song appears on.
+func (s *Storage) songAlbums(ctx context.Context, songID int) ([]storage.SongAlbum, error) {
+	rows, err := s.db.Query(ctx, `
+		SELECT al.album_id, al.title, al.release_date, t.track_number
+		FROM album_tracks t
+		JOIN albums al ON al.album_id = t.album_id
+		WHERE t.song_id = $1
+		ORDER BY al.album_id`, songID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (storage.SongAlbum, error) {
+		var album storage.SongAlbum
+		err := row.Scan(&album.ID, &album.Title, &album.ReleaseDate, &album.Track)
+		return album, err
+	})
+}
@@ -0,0 +1,296 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"song-library/internal/storage"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const selectArtists = `
+	SELECT a.artist_id, a.artist_name, COUNT(s.song_id)
+	FROM artists a
+	LEFT JOIN songs s ON s.artist_id = a.artist_id AND s.deleted_at IS NULL
+	`
+
+// ListArtists returns artists ordered by ID, with their song counts. Songs
+// in the trash are not counted.
+func (s *Storage) ListArtists(ctx context.Context, limit, offset int) ([]*storage.Artist, error) {
+	const op = "storage.postgres.ListArtists"
+
+	query := selectArtists + `
+		GROUP BY a.artist_id
+		ORDER BY a.artist_id
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := s.db.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var artists []*storage.Artist
+	for rows.Next() {
+		var artist storage.Artist
+		if err := rows.Scan(&artist.ID, &artist.Name, &artist.SongCount); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		artists = append(artists, &artist)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return artists, nil
+}
+
+func (s *Storage) GetArtist(ctx context.Context, id int) (*storage.Artist, error) {
+	const op = "storage.postgres.GetArtist"
+
+	query := selectArtists + `
+		WHERE a.artist_id = $1
+		GROUP BY a.artist_id
+	`
+
+	var artist storage.Artist
+	err := s.db.QueryRow(ctx, query, id).Scan(&artist.ID, &artist.Name, &artist.SongCount)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrArtistNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &artist, nil
+}
+
+// AddArtist creates an artist without songs and sets artist.ID.
+func (s *Storage) AddArtist(ctx context.Context, artist *storage.Artist) error {
+	const op = "storage.postgres.AddArtist"
+
+	if s.opts.NormalizeArtistNames {
+		artist.Name = storage.NormalizeArtistName(artist.Name)
+
+		var id int
+		err := s.db.QueryRow(ctx, s.artistByNameQuery(), artist.Name).Scan(&id)
+		if err == nil {
+			return fmt.Errorf("%s: %w", op, storage.ErrArtistExists)
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	err := s.db.QueryRow(ctx, "INSERT INTO artists(artist_name) VALUES($1) RETURNING artist_id", artist.Name).Scan(&artist.ID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%s: %w", op, storage.ErrArtistExists)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RenameArtist renames an artist. With normalized names it stores the
+// normalized form and refuses names matching another artist's.
+func (s *Storage) RenameArtist(ctx context.Context, id int, name string) error {
+	const op = "storage.postgres.RenameArtist"
+
+	if s.opts.NormalizeArtistNames {
+		name = storage.NormalizeArtistName(name)
+
+		var other int
+		err := s.db.QueryRow(ctx, s.artistByNameQuery(), name).Scan(&other)
+		if err == nil && other != id {
+			return fmt.Errorf("%s: %w", op, storage.ErrArtistExists)
+		}
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	res, err := s.db.Exec(ctx, "UPDATE artists SET artist_name = $1 WHERE artist_id = $2", name, id)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%s: %w", op, storage.ErrArtistExists)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return storage.ErrArtistNotFound
+	}
+
+	return nil
+}
+
+// DeleteArtist removes an artist, its albums and its credits on the songs
+// of other artists. Unless cascade is set, it refuses with
+// storage.ErrArtistHasSongs while songs still reference the artist, those
+// in the trash included. Cascading deletes the songs for good; those not
+// yet in the trash get a delete revision.
+func (s *Storage) DeleteArtist(ctx context.Context, id int, cascade bool) error {
+	const op = "storage.postgres.DeleteArtist"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	// The row lock keeps AddSong from attaching new songs meanwhile.
+	var songCount int
+	err = tx.QueryRow(ctx, `
+		SELECT (SELECT COUNT(*) FROM songs WHERE artist_id = a.artist_id)
+		FROM artists a
+		WHERE a.artist_id = $1
+		FOR UPDATE`, id).Scan(&songCount)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storage.ErrArtistNotFound
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if songCount > 0 && !cascade {
+		return fmt.Errorf("%s: %w", op, storage.ErrArtistHasSongs)
+	}
+
+	if songCount > 0 {
+		rows, err := tx.Query(ctx, "SELECT song_id FROM songs WHERE artist_id = $1 AND deleted_at IS NULL FOR UPDATE", id)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		songIDs, err := pgx.CollectRows(rows, pgx.RowTo[int])
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if err := recordRevisions(ctx, tx, storage.ActionDelete, songIDs); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	// songs, albums and song_artists are ON DELETE CASCADE.
+	if _, err := tx.Exec(ctx, "DELETE FROM artists WHERE artist_id = $1", id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// artistByNameQuery selects the ID of the artist named $1. With normalized
+// names it ignores case and whitespace, using idx_artists_name_normalized.
+func (s *Storage) artistByNameQuery() string {
+	if !s.opts.NormalizeArtistNames {
+		return "SELECT artist_id FROM artists WHERE artist_name = $1"
+	}
+	return `
+		SELECT artist_id FROM artists
+		WHERE lower(regexp_replace(btrim(artist_name), '\s+', ' ', 'g')) = lower($1)
+		ORDER BY artist_id
+		LIMIT 1`
+}
+
+// MergeArtists moves the songs, credits and albums of the source artists to the
+// target and deletes the sources, all in one transaction. If any titles would
+// collide under the target, nothing changes and a *storage.MergeConflictError
+// lists them. Songs in the trash move along but neither collide nor get the
+// update revision the other moved songs get. It returns the number of songs
+// moved outside the trash.
+func (s *Storage) MergeArtists(ctx context.Context, targetID int, sourceIDs []int) (int, error) {
+	const op = "storage.postgres.MergeArtists"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	ids := append([]int{targetID}, sourceIDs...)
+
+	// Locking the artists keeps songs from being attached to them meanwhile.
+	var locked int
+	err = tx.QueryRow(ctx, `
+		SELECT COUNT(*) FROM (
+			SELECT artist_id FROM artists WHERE artist_id = ANY($1) FOR UPDATE
+		) a`, ids).Scan(&locked)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if locked != len(ids) {
+		return 0, storage.ErrArtistNotFound
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT title, array_agg(song_id ORDER BY song_id)
+		FROM songs
+		WHERE artist_id = ANY($1) AND deleted_at IS NULL
+		GROUP BY title
+		HAVING COUNT(*) > 1
+		ORDER BY title`, ids)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	conflicts, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (storage.MergeConflict, error) {
+		var conflict storage.MergeConflict
+		err := row.Scan(&conflict.Title, &conflict.SongIDs)
+		return conflict, err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(conflicts) > 0 {
+		return 0, fmt.Errorf("%s: %w", op, &storage.MergeConflictError{Conflicts: conflicts})
+	}
+
+	rows, err = tx.Query(ctx, `
+		WITH moved AS (
+			UPDATE songs SET artist_id = $1 WHERE artist_id = ANY($2) RETURNING song_id, deleted_at
+		)
+		SELECT song_id FROM moved WHERE deleted_at IS NULL`, targetID, sourceIDs)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	movedIDs, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := mergeCredits(ctx, tx, targetID, sourceIDs); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Albums would otherwise be deleted along with their artists.
+	if _, err := tx.Exec(ctx, "UPDATE albums SET artist_id = $1 WHERE artist_id = ANY($2)", targetID, sourceIDs); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := tx.Exec(ctx, "DELETE FROM artists WHERE artist_id = ANY($1)", sourceIDs); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := recordRevisions(ctx, tx, storage.ActionUpdate, movedIDs); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return len(movedIDs), nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"song-library/internal/storage"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// roleRank orders the credits x like storage.RoleRank.
+const roleRank = "array_position(ARRAY['primary', 'featured', 'producer', 'composer']::varchar[], x.role)"
+
+// otherCredits selects the credits of song s other than the primary artist
+// as a JSON array of {"artist", "role"} objects, for revisions.
+const otherCredits = `COALESCE((
+	SELECT jsonb_agg(jsonb_build_object('artist', ca.artist_name, 'role', x.role) ORDER BY ` + roleRank + `, ca.artist_name, ca.artist_id)
+	` + creditedArtists + ` AND x.role <> 'primary'), '[]')`
+
+// creditedArtists joins the credited artists ca of song s; more conditions
+// may follow.
+const creditedArtists = `
+	FROM song_artists x
+	JOIN artists ca ON ca.artist_id = x.artist_id
+	WHERE x.song_id = s.song_id`
+
+// songCredits fills in the credits of songs, the primary artist first,
+// then by role and artist name.
+func (s *Storage) songCredits(ctx context.Context, songs []*storage.Song) error {
+	if len(songs) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(songs))
+	byID := make(map[int]*storage.Song, len(songs))
+	for i, song := range songs {
+		ids[i] = song.ID
+		byID[song.ID] = song
+	}
+
+	rows, err := s.db.Query(ctx, `
+		SELECT x.song_id, ca.artist_id, ca.artist_name, x.role
+		FROM song_artists x
+		JOIN artists ca ON ca.artist_id = x.artist_id
+		WHERE x.song_id = ANY($1)
+		ORDER BY x.song_id, `+roleRank+`, ca.artist_name, ca.artist_id`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			songID int
+			credit storage.Credit
+		)
+		if err := rows.Scan(&songID, &credit.ArtistID, &credit.Artist, &credit.Role); err != nil {
+			return err
+		}
+		byID[songID].Credits = append(byID[songID].Credits, credit)
+	}
+
+	return rows.Err()
+}
+
+// replaceCredits makes credits the only credits of the song besides its
+// primary artist, which the songs_sync_primary_artist trigger maintains.
+func (s *Storage) replaceCredits(ctx context.Context, tx pgx.Tx, songID int, credits []storage.Credit) error {
+	if _, err := tx.Exec(ctx, "DELETE FROM song_artists WHERE song_id = $1 AND role <> 'primary'", songID); err != nil {
+		return err
+	}
+
+	for _, credit := range credits {
+		artistID, err := s.ensureArtist(ctx, tx, credit.Artist)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO song_artists(song_id, artist_id, role) VALUES ($1, $2, $3)
+			ON CONFLICT DO NOTHING`, songID, artistID, credit.Role)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mergeCredits moves the credits of the source artists to the target.
+// Credits the target would then hold twice on a song are dropped.
+func mergeCredits(ctx context.Context, tx pgx.Tx, targetID int, sourceIDs []int) error {
+	_, err := tx.Exec(ctx, `
+		DELETE FROM song_artists x
+		WHERE x.artist_id = ANY($2) AND EXISTS (
+			SELECT 1 FROM song_artists y
+			WHERE y.song_id = x.song_id AND y.role = x.role
+			AND (y.artist_id = $1 OR (y.artist_id = ANY($2) AND y.artist_id < x.artist_id)))`, targetID, sourceIDs)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE song_artists SET artist_id = $1 WHERE artist_id = ANY($2)", targetID, sourceIDs)
+	return err
+}
@@ -0,0 +1,155 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"song-library/internal/storage"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const selectRevisions = `
+	SELECT song_id, revision, action, author, created_at,
+		artist_name, title, release_date, lyrics, link, genres, tags, credits
+	FROM song_revisions
+	`
+
+// recordRevisions stores the current state of the songs with these IDs as
+// their next revisions. The callers lock the songs, which keeps revision
+// numbers from colliding.
+func recordRevisions(ctx context.Context, tx pgx.Tx, action storage.RevisionAction, songIDs []int) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO song_revisions(song_id, revision, action, author,
+			artist_name, title, release_date, lyrics, link, genres, tags, credits)
+		SELECT s.song_id,
+			COALESCE((SELECT MAX(r.revision) FROM song_revisions r WHERE r.song_id = s.song_id), 0) + 1,
+			$1, $2, a.artist_name, s.title, s.release_date, COALESCE(s.lyrics, ''), COALESCE(s.link, ''),
+			`+songLabels+`, `+otherCredits+`
+		FROM songs s
+		JOIN artists a ON s.artist_id = a.artist_id
+		WHERE s.song_id = ANY($3)`, action, storage.Author(ctx), songIDs)
+	return err
+}
+
+func scanRevision(row pgx.Row) (*storage.Revision, error) {
+	var rev storage.Revision
+	err := row.Scan(&rev.SongID, &rev.Number, &rev.Action, &rev.Author, &rev.CreatedAt,
+		&rev.Song.Artist, &rev.Song.Title, &rev.Song.ReleaseDate, &rev.Song.Lyrics, &rev.Song.Link,
+		&rev.Song.Genres, &rev.Song.Tags, &rev.Song.Credits)
+	if err != nil {
+		return nil, err
+	}
+	return &rev, nil
+}
+
+// ListRevisions returns the revisions of a song, newest first. It returns
+// storage.ErrSongNotFound if the song never existed.
+func (s *Storage) ListRevisions(ctx context.Context, songID, limit, offset int) ([]*storage.Revision, error) {
+	const op = "storage.postgres.ListRevisions"
+
+	var count int
+	if err := s.db.QueryRow(ctx, "SELECT COUNT(*) FROM song_revisions WHERE song_id = $1", songID).Scan(&count); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if count == 0 {
+		return nil, storage.ErrSongNotFound
+	}
+
+	rows, err := s.db.Query(ctx, selectRevisions+`
+		WHERE song_id = $1
+		ORDER BY revision DESC
+		LIMIT $2 OFFSET $3`, songID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var revisions []*storage.Revision
+	for rows.Next() {
+		rev, err := scanRevision(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		revisions = append(revisions, rev)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return revisions, nil
+}
+
+func (s *Storage) GetRevision(ctx context.Context, songID, number int) (*storage.Revision, error) {
+	const op = "storage.postgres.GetRevision"
+
+	rev, err := scanRevision(s.db.QueryRow(ctx, selectRevisions+" WHERE song_id = $1 AND revision = $2", songID, number))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrRevisionNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rev, nil
+}
+
+// RestoreRevision brings the song back to the state of a revision and
+// records that as a new revision. A song in the trash is taken out of it;
+// a purged one is recreated under its old ID, without its album tracks.
+// It returns storage.ErrSongExists if the artist now has another song
+// with the restored title.
+func (s *Storage) RestoreRevision(ctx context.Context, songID, number int) error {
+	const op = "storage.postgres.RestoreRevision"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	rev, err := scanRevision(tx.QueryRow(ctx, selectRevisions+" WHERE song_id = $1 AND revision = $2", songID, number))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storage.ErrRevisionNotFound
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	snapshot := rev.Song
+
+	var trashed bool
+	err = tx.QueryRow(ctx, "SELECT deleted_at IS NOT NULL FROM songs WHERE song_id = $1 FOR UPDATE", songID).Scan(&trashed)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		snapshot.ID = songID
+		err = s.insertSong(ctx, tx, &snapshot)
+	case err == nil:
+		err = s.applyUpdate(ctx, tx, songID, &storage.SongUpdate{
+			Artist:      snapshot.Artist,
+			Title:       snapshot.Title,
+			ReleaseDate: &snapshot.ReleaseDate,
+			Lyrics:      &snapshot.Lyrics,
+			Link:        &snapshot.Link,
+			Genres:      &snapshot.Genres,
+			Tags:        &snapshot.Tags,
+			Credits:     &snapshot.Credits,
+		})
+		if err == nil && trashed {
+			err = untrash(ctx, tx, songID)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := recordRevisions(ctx, tx, storage.ActionRestore, []int{songID}); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"song-library/internal/storage"
 	"strings"
 
@@ -13,10 +14,11 @@ import (
 )
 
 type Storage struct {
-	db *pgxpool.Pool
+	db   *pgxpool.Pool
+	opts storage.Options
 }
 
-func New(connectionString string) (*Storage, error) {
+func New(connectionString string, opts storage.Options) (*Storage, error) {
 	const op = "storage.postgres.New"
 
 	db, err := pgxpool.New(context.Background(), connectionString)
@@ -24,62 +26,84 @@ func New(connectionString string) (*Storage, error) {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return &Storage{db: db}, nil
+	return &Storage{db: db, opts: opts}, nil
 }
 
-func (s *Storage) GetSongs(ctx context.Context, filter *map[string]string, limit, offset int) ([]*storage.Song, error) {
+// Ping checks that a connection to the database can be acquired and used.
+func (s *Storage) Ping(ctx context.Context) error {
+	const op = "storage.postgres.Ping"
+
+	if err := s.db.Ping(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Stat returns connection pool statistics.
+func (s *Storage) Stat() *pgxpool.Stat {
+	return s.db.Stat()
+}
+
+// Close waits for acquired connections to be released and closes the pool.
+func (s *Storage) Close() {
+	s.db.Close()
+}
+
+// releaseDate is the release date of song s or, if it has none, of its
+// earliest dated album. Missing dates are stored as 0001-01-01.
+const releaseDate = `COALESCE(
+	NULLIF(s.release_date, '0001-01-01'),
+	(SELECT MIN(al.release_date)
+	FROM album_tracks t
+	JOIN albums al ON al.album_id = t.album_id
+	WHERE t.song_id = s.song_id AND al.release_date > '0001-01-01'),
+	s.release_date)`
+
+// songLabels selects the genres and tags of song s.
+var songLabels = tagTables[storage.KindGenre].tagNames() + ", " + tagTables[storage.KindTag].tagNames()
+
+func (s *Storage) GetSongs(ctx context.Context, filter *storage.SongFilter, page storage.Page) ([]*storage.Song, error) {
 	const op = "storage.postgres.GetSongs"
 
 	query := `
-		SELECT artist_name, title, release_date, lyrics, link
+		SELECT s.song_id, a.artist_name, s.title, ` + releaseDate + `, s.lyrics, s.link, ` + songLabels + `, %s AS score
 		FROM songs s
 		JOIN artists a ON s.artist_id = a.artist_id
 		`
 
-	var conditions []string
-	var args []interface{}
+	conditions, args := filterConditions(filter)
 
-	if filter != nil {
-		if artist, ok := (*filter)["artist"]; ok {
-			conditions = append(conditions, fmt.Sprintf("a.artist_name ILIKE $%d", len(args)+1))
-			args = append(args, "%"+artist+"%")
-		}
-		if title, ok := (*filter)["title"]; ok {
-			conditions = append(conditions, fmt.Sprintf("s.title ILIKE $%d", len(args)+1))
-			args = append(args, "%"+title+"%")
-		}
-		if releaseDate, ok := (*filter)["release_date"]; ok {
-			dates := strings.Split(releaseDate, ",")
-			if len(dates) == 1 {
-				conditions = append(conditions, fmt.Sprintf("s.release_date = $%d", len(args)+1))
-				args = append(args, dates[0])
-			}
-			if len(dates) == 2 {
-				conditions = append(conditions, fmt.Sprintf("s.release_date BETWEEN $%d AND $%d", len(args)+1, len(args)+2))
-				args = append(args, dates[0], dates[1])
-			}
-		}
-		if lyrics, ok := (*filter)["lyrics"]; ok {
-			if lyrics == "not_null" {
-				conditions = append(conditions, "s.lyrics != ''")
-			} else {
-				conditions = append(conditions, fmt.Sprintf("s.lyrics ILIKE $%d", len(args)+1))
-				args = append(args, "%"+lyrics+"%")
-			}
-		}
-		if link, ok := (*filter)["link"]; ok {
-			if link == "not_null" {
-				conditions = append(conditions, "s.link != ''")
-			}
-		}
+	var score string
+	if filter != nil && filter.Fuzzy {
+		score, args = fuzzyScore(filter, args)
+	}
+	if score != "" {
+		query = fmt.Sprintf(query, score)
+	} else {
+		query = fmt.Sprintf(query, "0::real")
+	}
+
+	order := page.Order()
+	backward := page.Cursor != nil && page.Cursor.Backward
+	if page.Cursor != nil {
+		var keyset string
+		keyset, args = keysetCondition(order, page.Cursor, args)
+		conditions = append(conditions, keyset)
 	}
 
 	if len(conditions) > 0 {
 		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
 
+	query += " ORDER BY "
+	if score != "" {
+		query += "score DESC, "
+	}
+	query += orderBy(order, backward)
+
 	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
-	args = append(args, limit, offset)
+	args = append(args, page.Limit, page.Offset)
 
 	rows, err := s.db.Query(ctx, query, args...)
 	if err != nil {
@@ -92,10 +116,16 @@ func (s *Storage) GetSongs(ctx context.Context, filter *map[string]string, limit
 
 	var songs []*storage.Song
 	for rows.Next() {
-		var song storage.Song
-		if err := rows.Scan(&song.Artist, &song.Title, &song.ReleaseDate, &song.Lyrics, &song.Link); err != nil {
+		var (
+			song  storage.Song
+			score float32
+		)
+		err := rows.Scan(&song.ID, &song.Artist, &song.Title, &song.ReleaseDate, &song.Lyrics, &song.Link,
+			&song.Genres, &song.Tags, &score)
+		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
+		song.Score = float64(score)
 		songs = append(songs, &song)
 	}
 
@@ -103,9 +133,163 @@ func (s *Storage) GetSongs(ctx context.Context, filter *map[string]string, limit
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	if backward {
+		slices.Reverse(songs)
+	}
+
+	if err := s.songCredits(ctx, songs); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	return songs, nil
 }
 
+// CountSongs returns how many songs match filter, ignoring pagination.
+func (s *Storage) CountSongs(ctx context.Context, filter *storage.SongFilter) (int, error) {
+	const op = "storage.postgres.CountSongs"
+
+	query := `
+		SELECT COUNT(*)
+		FROM songs s
+		JOIN artists a ON s.artist_id = a.artist_id
+		`
+
+	conditions, args := filterConditions(filter)
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var count int
+	if err := s.db.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return count, nil
+}
+
+// escapedLyrics is s.lyrics with real newlines, escaped like
+// html.EscapeString so that ts_headline output can be rendered as HTML.
+const escapedLyrics = `replace(replace(replace(replace(replace(replace(s.lyrics,
+	'&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), '''', '&#39;'), '\n', E'\n')`
+
+// SearchLyrics runs a full-text search over the lyrics_tsv column and
+// returns the hits best first.
+func (s *Storage) SearchLyrics(ctx context.Context, query storage.LyricsQuery, limit, offset int) ([]*storage.SearchHit, error) {
+	const op = "storage.postgres.SearchLyrics"
+
+	sql := `
+		SELECT s.song_id, a.artist_name, s.title, ` + releaseDate + `, s.link,
+			ts_rank(s.lyrics_tsv, q) AS rank,
+			ts_headline($1::regconfig, ` + escapedLyrics + `, q, $2)
+		FROM songs s
+		JOIN artists a ON s.artist_id = a.artist_id,
+			websearch_to_tsquery($1::regconfig, $3) q
+		WHERE s.lyrics_tsv @@ q AND s.deleted_at IS NULL
+		ORDER BY rank DESC, s.song_id
+		LIMIT $4 OFFSET $5
+	`
+	options := fmt.Sprintf("StartSel=%s, StopSel=%s, MaxFragments=2", storage.HighlightStart, storage.HighlightStop)
+
+	rows, err := s.db.Query(ctx, sql, string(query.Config), options, query.Text, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var hits []*storage.SearchHit
+	for rows.Next() {
+		var (
+			hit  storage.SearchHit
+			rank float32
+		)
+		err := rows.Scan(&hit.Song.ID, &hit.Song.Artist, &hit.Song.Title, &hit.Song.ReleaseDate, &hit.Song.Link, &rank, &hit.Headline)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		hit.Rank = float64(rank)
+		hits = append(hits, &hit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return hits, nil
+}
+
+// Suggest completes artist names or song titles: names starting with
+// prefix come first, then names containing it, each alphabetically.
+func (s *Storage) Suggest(ctx context.Context, kind storage.SuggestKind, prefix string, limit int) ([]*storage.Suggestion, error) {
+	const op = "storage.postgres.Suggest"
+
+	var query string
+	switch kind {
+	case storage.SuggestArtist:
+		query = `
+			SELECT id, name, artist FROM (
+				(SELECT a.artist_id AS id, a.artist_name AS name, '' AS artist, 0 AS rank
+				FROM artists a
+				WHERE lower(a.artist_name) LIKE lower($1)
+				ORDER BY lower(a.artist_name), a.artist_id
+				LIMIT $3)
+				UNION ALL
+				(SELECT a.artist_id, a.artist_name, '', 1
+				FROM artists a
+				WHERE a.artist_name ILIKE $2 AND lower(a.artist_name) NOT LIKE lower($1)
+				ORDER BY lower(a.artist_name), a.artist_id
+				LIMIT $3)
+			) suggestions
+			ORDER BY rank, lower(name), id
+			LIMIT $3
+		`
+	case storage.SuggestSong:
+		query = `
+			SELECT id, name, artist FROM (
+				(SELECT s.song_id AS id, s.title AS name, a.artist_name AS artist, 0 AS rank
+				FROM songs s
+				JOIN artists a ON s.artist_id = a.artist_id
+				WHERE lower(s.title) LIKE lower($1) AND s.deleted_at IS NULL
+				ORDER BY lower(s.title), s.song_id
+				LIMIT $3)
+				UNION ALL
+				(SELECT s.song_id, s.title, a.artist_name, 1
+				FROM songs s
+				JOIN artists a ON s.artist_id = a.artist_id
+				WHERE s.title ILIKE $2 AND lower(s.title) NOT LIKE lower($1) AND s.deleted_at IS NULL
+				ORDER BY lower(s.title), s.song_id
+				LIMIT $3)
+			) suggestions
+			ORDER BY rank, lower(name), id
+			LIMIT $3
+		`
+	default:
+		return nil, fmt.Errorf("%s: %w: %q", op, storage.ErrInvalidSuggestKind, kind)
+	}
+
+	escaped := storage.EscapeLike(prefix)
+
+	rows, err := s.db.Query(ctx, query, escaped+"%", "%"+escaped+"%", limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var suggestions []*storage.Suggestion
+	for rows.Next() {
+		var suggestion storage.Suggestion
+		if err := rows.Scan(&suggestion.ID, &suggestion.Name, &suggestion.Artist); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		suggestions = append(suggestions, &suggestion)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return suggestions, nil
+}
+
 func (s *Storage) GetSongLyrics(ctx context.Context, artist, title string, limit, offset int) (string, error) {
 	const op = "storage.postgres.GetSongLyrics"
 
@@ -113,7 +297,7 @@ func (s *Storage) GetSongLyrics(ctx context.Context, artist, title string, limit
 		SELECT s.lyrics 
 		FROM songs s
 		JOIN artists a ON s.artist_id = a.artist_id
-		WHERE a.artist_name ILIKE $1 AND s.title ILIKE $2
+		WHERE a.artist_name ILIKE $1 AND s.title ILIKE $2 AND s.deleted_at IS NULL
 	`
 
 	var lyrics string
@@ -125,119 +309,206 @@ func (s *Storage) GetSongLyrics(ctx context.Context, artist, title string, limit
 		return "", fmt.Errorf("%s execute statement: %w", op, err)
 	}
 
-	verses := strings.Split(lyrics, "\\n\\n")
-	if offset >= len(verses) {
-		return "", nil
-	}
-
-	end := offset + limit
-	if end > len(verses) {
-		end = len(verses)
-	}
-
-	return strings.Join(verses[offset:end], "\\n\\n"), nil
+	return storage.PaginateVerses(lyrics, limit, offset), nil
 }
 
 func (s *Storage) DeleteSong(ctx context.Context, artist, title string) error {
 	const op = "storage.postgres.DeleteSong"
 
 	query := `
-		DELETE FROM songs s
-		USING artists a
-		WHERE s.artist_id = a.artist_id
-		AND a.artist_name = $1 AND s.title = $2
-	`
+		SELECT s.song_id
+		FROM songs s
+		JOIN artists a ON s.artist_id = a.artist_id
+		WHERE a.artist_name = $1 AND s.title = $2 AND s.deleted_at IS NULL
+		FOR UPDATE OF s`
+
+	return s.delete(ctx, op, query, artist, title)
+}
+
+// UpdateSong applies upd to the song with exactly matching artist and title.
+func (s *Storage) UpdateSong(ctx context.Context, artist, title string, upd *storage.SongUpdate) error {
+	const op = "storage.postgres.UpdateSong"
+
+	query := `
+		SELECT s.song_id
+		FROM songs s
+		JOIN artists a ON s.artist_id = a.artist_id
+		WHERE a.artist_name = $1 AND s.title = $2 AND s.deleted_at IS NULL
+		FOR UPDATE OF s`
 
-	res, err := s.db.Exec(ctx, query, artist, title)
+	return s.update(ctx, op, upd, query, artist, title)
+}
+
+func (s *Storage) AddSong(ctx context.Context, song *storage.Song) error {
+	const op = "storage.postgres.AddSong"
+
+	tx, err := s.db.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
+	defer tx.Rollback(ctx)
+
+	if song.Genres, err = storage.NormalizeTags(song.Genres); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if song.Tags, err = storage.NormalizeTags(song.Tags); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := storage.CheckCredits(song.Credits); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	song.ID = 0 // AddSong always assigns a new ID.
+	if err := s.insertSong(ctx, tx, song); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
 
-	rowsAffected := res.RowsAffected()
-	if rowsAffected == 0 {
-		return storage.ErrSongNotFound
+	if err := recordRevisions(ctx, tx, storage.ActionAdd, []int{song.ID}); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	return nil
 }
 
-func (s *Storage) UpdateSong(ctx context.Context, song *storage.Song) error {
-	const op = "storage.postgres.UpdateSong"
+// insertSong inserts song with its labels and credits, which must be
+// normalized and checked. A zero song.ID is set to a new one; RestoreRevision
+// sets it to bring back a deleted song under its old ID.
+func (s *Storage) insertSong(ctx context.Context, tx pgx.Tx, song *storage.Song) error {
+	artistID, err := s.ensureArtist(ctx, tx, song.Artist)
+	if err != nil {
+		return err
+	}
 
-	var (
-		setClauses []string
-		args       []interface{}
-		argIndex   = 1
-	)
+	if song.ID == 0 {
+		query := `INSERT INTO songs(artist_id, title, release_date, lyrics, link) VALUES ($1,$2,$3,$4,$5) RETURNING song_id`
+		err = tx.QueryRow(ctx, query, artistID, song.Title, song.ReleaseDate, song.Lyrics, song.Link).Scan(&song.ID)
+	} else {
+		query := `INSERT INTO songs(song_id, artist_id, title, release_date, lyrics, link) VALUES ($1,$2,$3,$4,$5,$6)`
+		_, err = tx.Exec(ctx, query, song.ID, artistID, song.Title, song.ReleaseDate, song.Lyrics, song.Link)
+	}
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // 23505 - уникальное ограничение нарушено
+			return storage.ErrSongExists
+		}
+		return err
+	}
+
+	if err := tagTables[storage.KindGenre].attach(ctx, tx, song.ID, song.Genres); err != nil {
+		return err
+	}
+	if err := tagTables[storage.KindTag].attach(ctx, tx, song.ID, song.Tags); err != nil {
+		return err
+	}
+	return s.replaceCredits(ctx, tx, song.ID, song.Credits)
+}
+
+func (s *Storage) GetSong(ctx context.Context, artist, title string) (*storage.Song, error) {
+	const op = "storage.postgres.GetSong"
+
+	query := `
+		SELECT s.song_id, a.artist_name, s.title, ` + releaseDate + `, s.lyrics, s.link, ` + songLabels + `
+		FROM songs s
+		JOIN artists a ON s.artist_id = a.artist_id
+		WHERE a.artist_name ILIKE $1 AND s.title ILIKE $2 AND s.deleted_at IS NULL`
 
-	if song.Lyrics != "" {
-		setClauses = append(setClauses, fmt.Sprintf("lyrics = $%d", argIndex))
-		args = append(args, song.Lyrics)
-		argIndex++
+	var song storage.Song
+	err := s.db.QueryRow(ctx, query, artist, title).
+		Scan(&song.ID, &song.Artist, &song.Title, &song.ReleaseDate, &song.Lyrics, &song.Link, &song.Genres, &song.Tags)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrSongNotFound
+		}
+		return nil, fmt.Errorf("%s execute statement: %w", op, err)
 	}
-	if song.Link != "" {
-		setClauses = append(setClauses, fmt.Sprintf("link = $%d", argIndex))
-		args = append(args, song.Link)
-		argIndex++
+
+	if song.Albums, err = s.songAlbums(ctx, song.ID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	if !song.ReleaseDate.IsZero() {
-		setClauses = append(setClauses, fmt.Sprintf("release_date = $%d", argIndex))
-		args = append(args, song.ReleaseDate)
-		argIndex++
+	if err := s.songCredits(ctx, []*storage.Song{&song}); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if len(setClauses) == 0 {
-		return storage.NothingChanged
+	return &song, nil
+}
+
+func (s *Storage) GetSongByID(ctx context.Context, id int) (*storage.Song, error) {
+	const op = "storage.postgres.GetSongByID"
+
+	query := `
+		SELECT s.song_id, a.artist_name, s.title, ` + releaseDate + `, s.lyrics, s.link, ` + songLabels + `
+		FROM songs s
+		JOIN artists a ON s.artist_id = a.artist_id
+		WHERE s.song_id = $1 AND s.deleted_at IS NULL`
+
+	var song storage.Song
+	err := s.db.QueryRow(ctx, query, id).
+		Scan(&song.ID, &song.Artist, &song.Title, &song.ReleaseDate, &song.Lyrics, &song.Link, &song.Genres, &song.Tags)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrSongNotFound
+		}
+		return nil, fmt.Errorf("%s execute statement: %w", op, err)
 	}
 
-	query := fmt.Sprintf(`UPDATE songs s
-		SET %s
-		FROM artists a
-		WHERE s.artist_id = a.artist_id
-		AND a.artist_name = $%d
-		AND s.title = $%d`,
-		strings.Join(setClauses, ", "), argIndex, argIndex+1)
+	if song.Albums, err = s.songAlbums(ctx, song.ID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.songCredits(ctx, []*storage.Song{&song}); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &song, nil
+}
 
-	args = append(args, song.Artist, song.Title)
+func (s *Storage) GetSongLyricsByID(ctx context.Context, id, limit, offset int) (string, error) {
+	const op = "storage.postgres.GetSongLyricsByID"
 
-	_, err := s.db.Exec(ctx, query, args...)
+	var lyrics string
+	err := s.db.QueryRow(ctx, "SELECT lyrics FROM songs WHERE song_id = $1 AND deleted_at IS NULL", id).Scan(&lyrics)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", storage.ErrSongNotFound
+		}
+		return "", fmt.Errorf("%s execute statement: %w", op, err)
 	}
 
-	return nil
+	return storage.PaginateVerses(lyrics, limit, offset), nil
 }
 
-func (s *Storage) AddSong(ctx context.Context, song *storage.Song) error {
-	const op = "storage.postgres.AddSong"
+func (s *Storage) DeleteSongByID(ctx context.Context, id int) error {
+	const op = "storage.postgres.DeleteSongByID"
 
+	return s.delete(ctx, op, "SELECT song_id FROM songs WHERE song_id = $1 AND deleted_at IS NULL FOR UPDATE", id)
+}
+
+// delete locks the song selected by lockQuery, records its last revision
+// and moves it to the trash in one transaction.
+func (s *Storage) delete(ctx context.Context, op string, lockQuery string, args ...interface{}) error {
 	tx, err := s.db.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 	defer tx.Rollback(ctx)
 
-	var artistID int
-	err = tx.QueryRow(ctx, "SELECT artist_id FROM artists WHERE artist_name = $1", song.Artist).Scan(&artistID)
+	var songID int
+	err = tx.QueryRow(ctx, lockQuery, args...).Scan(&songID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			err = tx.QueryRow(ctx, "INSERT INTO artists(artist_name) VALUES($1) RETURNING artist_id", song.Artist).Scan(&artistID)
-			if err != nil {
-				return fmt.Errorf("%s: %w", op, err)
-			}
-		} else {
-			return fmt.Errorf("%s: %w", op, err)
+			return storage.ErrSongNotFound
 		}
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	query := `INSERT INTO songs(artist_id, title, release_date, lyrics, link) VALUES ($1,$2,$3,$4,$5)`
+	if err := recordRevisions(ctx, tx, storage.ActionDelete, []int{songID}); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
 
-	_, err = tx.Exec(ctx, query, artistID, song.Title, song.ReleaseDate, song.Lyrics, song.Link)
-	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // 23505 - уникальное ограничение нарушено
-			return fmt.Errorf("%s: %w", op, storage.ErrSongExists)
-		}
+	if _, err := tx.Exec(ctx, "UPDATE songs SET deleted_at = now() WHERE song_id = $1", songID); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -248,23 +519,323 @@ func (s *Storage) AddSong(ctx context.Context, song *storage.Song) error {
 	return nil
 }
 
-func (s *Storage) GetSong(ctx context.Context, artist, title string) (*storage.Song, error) {
-	const op = "storage.postgres.GetSong"
+func (s *Storage) UpdateSongByID(ctx context.Context, id int, upd *storage.SongUpdate) error {
+	const op = "storage.postgres.UpdateSongByID"
 
-	query := `
-		SELECT a.artist_name, s.title, s.release_date, s.lyrics, s.link 
-		FROM songs s
-		JOIN artists a ON s.artist_id = a.artist_id
-		WHERE a.artist_name ILIKE $1 AND s.title ILIKE $2`
+	return s.update(ctx, op, upd, "SELECT song_id FROM songs WHERE song_id = $1 AND deleted_at IS NULL FOR UPDATE", id)
+}
 
-	var song storage.Song
-	err := s.db.QueryRow(ctx, query, artist, title).Scan(&song.Artist, &song.Title, &song.ReleaseDate, &song.Lyrics, &song.Link)
+// update locks the song selected by lockQuery, applies upd and records a
+// revision in one transaction. Moving the song to a missing artist creates
+// that artist.
+func (s *Storage) update(ctx context.Context, op string, upd *storage.SongUpdate, lockQuery string, args ...interface{}) error {
+	if upd.IsEmpty() {
+		return storage.NothingChanged
+	}
+
+	upd, err := normalizeUpdate(upd)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var songID int
+	err = tx.QueryRow(ctx, lockQuery, args...).Scan(&songID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, storage.ErrSongNotFound
+			return storage.ErrSongNotFound
 		}
-		return nil, fmt.Errorf("%s execute statement: %w", op, err)
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	return &song, nil
+	if err := s.applyUpdate(ctx, tx, songID, upd); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := recordRevisions(ctx, tx, storage.ActionUpdate, []int{songID}); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// normalizeUpdate returns a copy of upd with normalized labels, checking
+// them and the credits.
+func normalizeUpdate(upd *storage.SongUpdate) (*storage.SongUpdate, error) {
+	res := *upd
+	if upd.Genres != nil {
+		genres, err := storage.NormalizeTags(*upd.Genres)
+		if err != nil {
+			return nil, err
+		}
+		res.Genres = &genres
+	}
+	if upd.Tags != nil {
+		tags, err := storage.NormalizeTags(*upd.Tags)
+		if err != nil {
+			return nil, err
+		}
+		res.Tags = &tags
+	}
+	if res.Credits != nil {
+		if err := storage.CheckCredits(*res.Credits); err != nil {
+			return nil, err
+		}
+	}
+	return &res, nil
+}
+
+// applyUpdate applies upd, normalized, to the locked song.
+func (s *Storage) applyUpdate(ctx context.Context, tx pgx.Tx, songID int, upd *storage.SongUpdate) error {
+	var (
+		setClauses []string
+		setArgs    []interface{}
+	)
+	set := func(column string, value interface{}) {
+		setArgs = append(setArgs, value)
+		setClauses = append(setClauses, fmt.Sprintf("%s = $%d", column, len(setArgs)))
+	}
+
+	if upd.Artist != "" {
+		artistID, err := s.ensureArtist(ctx, tx, upd.Artist)
+		if err != nil {
+			return err
+		}
+		set("artist_id", artistID)
+	}
+	if upd.Title != "" {
+		set("title", upd.Title)
+	}
+	if upd.Lyrics != nil {
+		set("lyrics", *upd.Lyrics)
+	}
+	if upd.Link != nil {
+		set("link", *upd.Link)
+	}
+	if upd.ReleaseDate != nil {
+		set("release_date", *upd.ReleaseDate)
+	}
+
+	if len(setClauses) > 0 {
+		query := fmt.Sprintf("UPDATE songs SET %s WHERE song_id = $%d", strings.Join(setClauses, ", "), len(setArgs)+1)
+
+		_, err := tx.Exec(ctx, query, append(setArgs, songID)...)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return storage.ErrSongExists
+			}
+			return err
+		}
+	}
+
+	if upd.Genres != nil {
+		if err := tagTables[storage.KindGenre].replace(ctx, tx, songID, *upd.Genres); err != nil {
+			return err
+		}
+	}
+	if upd.Tags != nil {
+		if err := tagTables[storage.KindTag].replace(ctx, tx, songID, *upd.Tags); err != nil {
+			return err
+		}
+	}
+	if upd.Credits != nil {
+		if err := s.replaceCredits(ctx, tx, songID, *upd.Credits); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sortColumns whitelists the columns GetSongs may order by. Names compare
+// byte-wise under the "C" collation, whatever the database default, so
+// orders and cursors match the memory backend.
+var sortColumns = map[storage.SortKey]string{
+	storage.SortID:          "s.song_id",
+	storage.SortArtist:      `a.artist_name COLLATE "C"`,
+	storage.SortTitle:       `s.title COLLATE "C"`,
+	storage.SortReleaseDate: releaseDate,
+}
+
+// orderBy builds the ORDER BY list. Backward pages are read in reverse
+// and flipped back by the caller.
+func orderBy(order []storage.SortField, backward bool) string {
+	terms := make([]string, len(order))
+	for i, field := range order {
+		terms[i] = sortColumns[field.Key]
+		if field.Desc != backward {
+			terms[i] += " DESC"
+		}
+	}
+	return strings.Join(terms, ", ")
+}
+
+// keysetCondition selects the rows after the cursor in the given order:
+// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ..., with '<' for descending keys.
+func keysetCondition(order []storage.SortField, cursor *storage.Cursor, args []interface{}) (string, []interface{}) {
+	var (
+		alternatives []string
+		equalities   []string
+	)
+	for _, field := range order {
+		column := sortColumns[field.Key]
+
+		var value interface{}
+		switch field.Key {
+		case storage.SortID:
+			value = cursor.ID
+		case storage.SortArtist:
+			value = cursor.Artist
+		case storage.SortTitle:
+			value = cursor.Title
+		case storage.SortReleaseDate:
+			value = cursor.ReleaseDate
+		}
+		args = append(args, value)
+
+		cmp := ">"
+		if field.Desc != cursor.Backward {
+			cmp = "<"
+		}
+
+		terms := append(slices.Clone(equalities), fmt.Sprintf("%s %s $%d", column, cmp, len(args)))
+		alternatives = append(alternatives, "("+strings.Join(terms, " AND ")+")")
+		equalities = append(equalities, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	return "(" + strings.Join(alternatives, " OR ") + ")", args
+}
+
+// fuzzyScore returns the similarity expression of a fuzzy filter: the
+// mean similarity of the artist and title terms that are set, where the
+// artist term is that of the best-matching credited artist. It is empty
+// if neither is.
+func fuzzyScore(filter *storage.SongFilter, args []interface{}) (string, []interface{}) {
+	var terms []string
+	if filter.Artist != "" {
+		args = append(args, filter.Artist)
+		terms = append(terms, fmt.Sprintf("(SELECT MAX(similarity(ca.artist_name, $%d)) %s)", len(args), creditedArtists))
+	}
+	if filter.Title != "" {
+		args = append(args, filter.Title)
+		terms = append(terms, fmt.Sprintf("similarity(s.title, $%d)", len(args)))
+	}
+	if len(terms) == 0 {
+		return "", args
+	}
+
+	return fmt.Sprintf("((%s) / %d)::real", strings.Join(terms, " + "), len(terms)), args
+}
+
+// filterConditions translates filter into WHERE conditions over songs s
+// joined with artists a. Placeholders are numbered from $1.
+func filterConditions(filter *storage.SongFilter) ([]string, []interface{}) {
+	var (
+		conditions = []string{"s.deleted_at IS NULL"} // Songs in the trash are hidden.
+		args       []interface{}
+	)
+	if filter == nil {
+		return conditions, args
+	}
+
+	if filter.Fuzzy {
+		// % uses pg_trgm.similarity_threshold, storage.FuzzyThreshold by default.
+		if filter.Artist != "" {
+			args = append(args, filter.Artist)
+			conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 %s AND ca.artist_name %% $%d)", creditedArtists, len(args)))
+		}
+		if filter.Title != "" {
+			args = append(args, filter.Title)
+			conditions = append(conditions, fmt.Sprintf("s.title %% $%d", len(args)))
+		}
+	} else {
+		if filter.Artist != "" {
+			args = append(args, "%"+storage.EscapeLike(filter.Artist)+"%")
+			conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 %s AND ca.artist_name ILIKE $%d)", creditedArtists, len(args)))
+		}
+		if filter.Title != "" {
+			args = append(args, "%"+storage.EscapeLike(filter.Title)+"%")
+			conditions = append(conditions, fmt.Sprintf("s.title ILIKE $%d", len(args)))
+		}
+	}
+	if filter.ArtistID != 0 {
+		args = append(args, filter.ArtistID)
+		conditions = append(conditions, fmt.Sprintf("s.artist_id = $%d", len(args)))
+	}
+	if filter.Album != "" {
+		args = append(args, "%"+storage.EscapeLike(filter.Album)+"%")
+		conditions = append(conditions, fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM album_tracks t
+			JOIN albums al ON al.album_id = t.album_id
+			WHERE t.song_id = s.song_id AND al.title ILIKE $%d)`, len(args)))
+	}
+	if filter.Lyrics != "" {
+		args = append(args, "%"+storage.EscapeLike(filter.Lyrics)+"%")
+		conditions = append(conditions, fmt.Sprintf("s.lyrics ILIKE $%d", len(args)))
+	}
+	if !filter.ReleasedFrom.IsZero() {
+		args = append(args, filter.ReleasedFrom)
+		conditions = append(conditions, fmt.Sprintf("%s >= $%d", releaseDate, len(args)))
+	}
+	if !filter.ReleasedTo.IsZero() {
+		args = append(args, filter.ReleasedTo)
+		conditions = append(conditions, fmt.Sprintf("%s <= $%d", releaseDate, len(args)))
+		if filter.ReleasedFrom.IsZero() {
+			// Songs without a release date are stored as 0001-01-01.
+			conditions = append(conditions, releaseDate+" > '0001-01-01'")
+		}
+	}
+
+	if len(filter.Genres.Names) > 0 {
+		args = append(args, filter.Genres.Names)
+		conditions = append(conditions, tagTables[storage.KindGenre].condition(filter.Genres, len(args)))
+	}
+	if len(filter.Tags.Names) > 0 {
+		args = append(args, filter.Tags.Names)
+		conditions = append(conditions, tagTables[storage.KindTag].condition(filter.Tags, len(args)))
+	}
+
+	switch filter.HasLyrics {
+	case storage.PresencePresent:
+		conditions = append(conditions, "s.lyrics != ''")
+	case storage.PresenceAbsent:
+		conditions = append(conditions, "COALESCE(s.lyrics, '') = ''")
+	}
+	switch filter.HasLink {
+	case storage.PresencePresent:
+		conditions = append(conditions, "s.link != ''")
+	case storage.PresenceAbsent:
+		conditions = append(conditions, "COALESCE(s.link, '') = ''")
+	}
+
+	return conditions, args
+}
+
+// ensureArtist returns the ID of the artist with this name, creating it if
+// needed. Names match exactly unless artist names are normalized.
+func (s *Storage) ensureArtist(ctx context.Context, tx pgx.Tx, name string) (int, error) {
+	if s.opts.NormalizeArtistNames {
+		name = storage.NormalizeArtistName(name)
+	}
+
+	var artistID int
+	err := tx.QueryRow(ctx, s.artistByNameQuery(), name).Scan(&artistID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = tx.QueryRow(ctx, "INSERT INTO artists(artist_name) VALUES($1) RETURNING artist_id", name).Scan(&artistID)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return artistID, nil
 }
@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"song-library/internal/migrator"
+	"song-library/internal/storage"
+	"song-library/internal/storage/storagetest"
+	"song-library/migrations"
+)
+
+// TestStorage runs against the database in TEST_CONNECTION_STRING.
+// Pending migrations are applied and tables are truncated before every test.
+func TestStorage(t *testing.T) {
+	connectionString := os.Getenv("TEST_CONNECTION_STRING")
+	if connectionString == "" {
+		t.Skip("TEST_CONNECTION_STRING is not set")
+	}
+
+	m, err := migrator.New(context.Background(), connectionString, migrations.FS)
+	if err != nil {
+		t.Fatalf("migrator.New: %v", err)
+	}
+	defer m.Close(context.Background())
+
+	if _, err := m.Up(context.Background()); err != nil {
+		t.Fatalf("migrate up: %v", err)
+	}
+
+	storagetest.Run(t, func(t *testing.T, opts storage.Options) storage.Storage {
+		s, err := New(connectionString, opts)
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		t.Cleanup(s.Close)
+
+		_, err = s.db.Exec(context.Background(), "TRUNCATE songs, artists, song_revisions, genres, tags RESTART IDENTITY CASCADE")
+		if err != nil {
+			t.Fatalf("truncate tables: %v", err)
+		}
+
+		return s
+	})
+}
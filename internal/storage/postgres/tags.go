@@ -0,0 +1,175 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"song-library/internal/storage"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// tagTable describes the tables of a tag kind: labels holds the names,
+// links ties them to songs through the id column.
+type tagTable struct {
+	labels, id, links string
+}
+
+var tagTables = map[storage.TagKind]tagTable{
+	storage.KindGenre: {labels: "genres", id: "genre_id", links: "song_genres"},
+	storage.KindTag:   {labels: "tags", id: "tag_id", links: "song_tags"},
+}
+
+// tagNames selects the sorted label names of song s.
+func (t tagTable) tagNames() string {
+	return fmt.Sprintf(`ARRAY(
+		SELECT l.name FROM %[1]s x
+		JOIN %[2]s l ON l.%[3]s = x.%[3]s
+		WHERE x.song_id = s.song_id
+		ORDER BY l.name)`, t.links, t.labels, t.id)
+}
+
+// condition keeps the songs s matching filter. $%d is the names array.
+func (t tagTable) condition(filter storage.TagFilter, arg int) string {
+	matches := fmt.Sprintf(`
+		FROM %[1]s x
+		JOIN %[2]s l ON l.%[3]s = x.%[3]s
+		WHERE x.song_id = s.song_id AND l.name = ANY($%[4]d)`, t.links, t.labels, t.id, arg)
+	if filter.All {
+		return fmt.Sprintf("(SELECT COUNT(*) %s) = %d", matches, len(filter.Names))
+	}
+	return "EXISTS (SELECT 1 " + matches + ")"
+}
+
+// attach labels the song with names, creating the labels as needed.
+func (t tagTable) attach(ctx context.Context, tx pgx.Tx, songID int, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s(name) SELECT unnest($1::text[]) ON CONFLICT (name) DO NOTHING", t.labels)
+	if _, err := tx.Exec(ctx, query, names); err != nil {
+		return err
+	}
+
+	query = fmt.Sprintf(`
+		INSERT INTO %[1]s(song_id, %[3]s)
+		SELECT $1, %[3]s FROM %[2]s WHERE name = ANY($2)
+		ON CONFLICT DO NOTHING`, t.links, t.labels, t.id)
+	_, err := tx.Exec(ctx, query, songID, names)
+	return err
+}
+
+// detach removes names from the labels of the song. The labels themselves stay.
+func (t tagTable) detach(ctx context.Context, tx pgx.Tx, songID int, names []string) error {
+	query := fmt.Sprintf(`
+		DELETE FROM %[1]s
+		WHERE song_id = $1 AND %[3]s IN (SELECT %[3]s FROM %[2]s WHERE name = ANY($2))`, t.links, t.labels, t.id)
+	_, err := tx.Exec(ctx, query, songID, names)
+	return err
+}
+
+// replace makes names the only labels of the song.
+func (t tagTable) replace(ctx context.Context, tx pgx.Tx, songID int, names []string) error {
+	if _, err := tx.Exec(ctx, fmt.Sprintf("DELETE FROM %s WHERE song_id = $1", t.links), songID); err != nil {
+		return err
+	}
+	return t.attach(ctx, tx, songID, names)
+}
+
+// TagSong adds genres or tags to a song. Names it already has are ignored.
+func (s *Storage) TagSong(ctx context.Context, songID int, kind storage.TagKind, names []string) error {
+	const op = "storage.postgres.TagSong"
+
+	return s.changeTags(ctx, op, songID, kind, names, tagTable.attach)
+}
+
+// UntagSong removes genres or tags from a song. Names it does not have are ignored.
+func (s *Storage) UntagSong(ctx context.Context, songID int, kind storage.TagKind, names []string) error {
+	const op = "storage.postgres.UntagSong"
+
+	return s.changeTags(ctx, op, songID, kind, names, tagTable.detach)
+}
+
+func (s *Storage) changeTags(ctx context.Context, op string, songID int, kind storage.TagKind, names []string,
+	change func(tagTable, context.Context, pgx.Tx, int, []string) error) error {
+	table, ok := tagTables[kind]
+	if !ok {
+		return fmt.Errorf("%s: unknown tag kind %q", op, kind)
+	}
+
+	names, err := storage.NormalizeTags(names)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var id int
+	err = tx.QueryRow(ctx, "SELECT song_id FROM songs WHERE song_id = $1 AND deleted_at IS NULL FOR UPDATE", songID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storage.ErrSongNotFound
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := change(table, ctx, tx, songID, names); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := recordRevisions(ctx, tx, storage.ActionUpdate, []int{songID}); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ListTags returns the genres or tags in use, most used first. Songs in
+// the trash are not counted.
+func (s *Storage) ListTags(ctx context.Context, kind storage.TagKind, limit, offset int) ([]*storage.TagUsage, error) {
+	const op = "storage.postgres.ListTags"
+
+	table, ok := tagTables[kind]
+	if !ok {
+		return nil, fmt.Errorf("%s: unknown tag kind %q", op, kind)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT l.name, COUNT(*)
+		FROM %[1]s x
+		JOIN %[2]s l ON l.%[3]s = x.%[3]s
+		JOIN songs s ON s.song_id = x.song_id AND s.deleted_at IS NULL
+		GROUP BY l.name
+		ORDER BY COUNT(*) DESC, l.name
+		LIMIT $1 OFFSET $2`, table.links, table.labels, table.id)
+
+	rows, err := s.db.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var usages []*storage.TagUsage
+	for rows.Next() {
+		var usage storage.TagUsage
+		if err := rows.Scan(&usage.Name, &usage.SongCount); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		usages = append(usages, &usage)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return usages, nil
+}
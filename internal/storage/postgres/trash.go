@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"song-library/internal/storage"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ListTrash returns the songs in the trash, most recently deleted first.
+func (s *Storage) ListTrash(ctx context.Context, limit, offset int) ([]*storage.TrashedSong, error) {
+	const op = "storage.postgres.ListTrash"
+
+	rows, err := s.db.Query(ctx, `
+		SELECT s.song_id, a.artist_name, s.title, s.deleted_at
+		FROM songs s
+		JOIN artists a ON s.artist_id = a.artist_id
+		WHERE s.deleted_at IS NOT NULL
+		ORDER BY s.deleted_at DESC, s.song_id DESC
+		LIMIT $1 OFFSET $2`, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var songs []*storage.TrashedSong
+	for rows.Next() {
+		var song storage.TrashedSong
+		if err := rows.Scan(&song.ID, &song.Artist, &song.Title, &song.DeletedAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		songs = append(songs, &song)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return songs, nil
+}
+
+// RestoreSong takes a song out of the trash as it was deleted and records
+// a restore revision. It returns storage.ErrSongNotFound unless the song is
+// in the trash, and storage.ErrSongExists if the artist has since got
+// another song with its title.
+func (s *Storage) RestoreSong(ctx context.Context, id int) error {
+	const op = "storage.postgres.RestoreSong"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var songID int
+	err = tx.QueryRow(ctx, "SELECT song_id FROM songs WHERE song_id = $1 AND deleted_at IS NOT NULL FOR UPDATE", id).Scan(&songID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storage.ErrSongNotFound
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := untrash(ctx, tx, songID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := recordRevisions(ctx, tx, storage.ActionRestore, []int{songID}); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// PurgeTrash deletes the songs moved to the trash before the given time for
+// good, along with their album tracks. Their revisions remain. It returns
+// the number of songs purged.
+func (s *Storage) PurgeTrash(ctx context.Context, before time.Time) (int, error) {
+	const op = "storage.postgres.PurgeTrash"
+
+	res, err := s.db.Exec(ctx, "DELETE FROM songs WHERE deleted_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return int(res.RowsAffected()), nil
+}
+
+// untrash takes the locked song out of the trash. It returns
+// storage.ErrSongExists if the artist has another song with its title.
+func untrash(ctx context.Context, tx pgx.Tx, songID int) error {
+	_, err := tx.Exec(ctx, "UPDATE songs SET deleted_at = NULL WHERE song_id = $1", songID)
+	if isUniqueViolation(err) {
+		return storage.ErrSongExists
+	}
+	return err
+}
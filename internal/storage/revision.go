@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// RevisionAction is the kind of change a revision records.
+type RevisionAction string
+
+const (
+	ActionAdd     RevisionAction = "add"
+	ActionUpdate  RevisionAction = "update"
+	ActionDelete  RevisionAction = "delete"
+	ActionRestore RevisionAction = "restore"
+)
+
+// Anonymous is the author of changes made without one in the context.
+const Anonymous = "anonymous"
+
+// Revision is an immutable snapshot of a song, recorded on every change.
+// Revisions of a song are numbered from 1. A delete revision holds the
+// song as it was before the delete, the others as it was after the change.
+type Revision struct {
+	SongID    int
+	Number    int
+	Action    RevisionAction
+	Author    string
+	CreatedAt time.Time
+	// Song holds the fields of the song itself: its own release date and
+	// credits other than the primary artist, without albums or ID.
+	Song Song
+}
+
+type authorKey struct{}
+
+// WithAuthor returns a copy of ctx naming the author of the changes made
+// with it.
+func WithAuthor(ctx context.Context, author string) context.Context {
+	return context.WithValue(ctx, authorKey{}, author)
+}
+
+// Author returns the author stored in ctx by WithAuthor, or Anonymous.
+func Author(ctx context.Context) string {
+	if author, ok := ctx.Value(authorKey{}).(string); ok && author != "" {
+		return author
+	}
+	return Anonymous
+}
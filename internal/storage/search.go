@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidSearchConfig = errors.New("invalid text search configuration")
+
+// SearchConfig is a Postgres text search configuration usable in
+// SearchLyrics.
+type SearchConfig string
+
+const (
+	SearchSimple  SearchConfig = "simple"
+	SearchEnglish SearchConfig = "english"
+	SearchRussian SearchConfig = "russian"
+)
+
+// Highlight markers around matched words in SearchHit.Headline.
+const (
+	HighlightStart = "<mark>"
+	HighlightStop  = "</mark>"
+)
+
+// ParseSearchConfig validates a configuration name. An empty name selects
+// SearchSimple, which matches words as written in either language.
+func ParseSearchConfig(name string) (SearchConfig, error) {
+	switch config := SearchConfig(name); config {
+	case "":
+		return SearchSimple, nil
+	case SearchSimple, SearchEnglish, SearchRussian:
+		return config, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidSearchConfig, name)
+	}
+}
+
+// LyricsQuery is a full-text lyrics search in web search syntax:
+// words are ANDed, "quoted text" is a phrase, "or" separates
+// alternatives and a leading '-' excludes a word.
+type LyricsQuery struct {
+	Text   string
+	Config SearchConfig
+}
+
+// SearchHit is a song matching a LyricsQuery. Song.Lyrics is left empty;
+// Headline holds HTML-escaped fragments of the lyrics with matched words
+// wrapped in HighlightStart and HighlightStop.
+type SearchHit struct {
+	Song     Song
+	Rank     float64
+	Headline string
+}
@@ -6,13 +6,50 @@ import (
 	"time"
 )
 
+// Options tune the behaviour shared by all backends.
+type Options struct {
+	// NormalizeArtistNames makes new songs and artists reuse an existing
+	// artist whose name differs only in case and whitespace. New names
+	// are stored trimmed, with inner whitespace collapsed.
+	NormalizeArtistNames bool
+}
+
 type Storage interface {
-	GetSongs(ctx context.Context, filter *map[string]string, limit, offset int) ([]*Song, error)
+	GetSongs(ctx context.Context, filter *SongFilter, page Page) ([]*Song, error)
+	CountSongs(ctx context.Context, filter *SongFilter) (int, error)
+	SearchLyrics(ctx context.Context, query LyricsQuery, limit, offset int) ([]*SearchHit, error)
+	Suggest(ctx context.Context, kind SuggestKind, prefix string, limit int) ([]*Suggestion, error)
 	GetSongLyrics(ctx context.Context, artist, title string, limit, offset int) (string, error)
 	DeleteSong(ctx context.Context, artist, title string) error
-	UpdateSong(ctx context.Context, song *Song) error
+	UpdateSong(ctx context.Context, artist, title string, upd *SongUpdate) error
 	AddSong(ctx context.Context, song *Song) error
 	GetSong(ctx context.Context, artist, title string) (*Song, error)
+	GetSongByID(ctx context.Context, id int) (*Song, error)
+	GetSongLyricsByID(ctx context.Context, id, limit, offset int) (string, error)
+	DeleteSongByID(ctx context.Context, id int) error
+	UpdateSongByID(ctx context.Context, id int, upd *SongUpdate) error
+	ListArtists(ctx context.Context, limit, offset int) ([]*Artist, error)
+	GetArtist(ctx context.Context, id int) (*Artist, error)
+	AddArtist(ctx context.Context, artist *Artist) error
+	RenameArtist(ctx context.Context, id int, name string) error
+	DeleteArtist(ctx context.Context, id int, cascade bool) error
+	MergeArtists(ctx context.Context, targetID int, sourceIDs []int) (int, error)
+	ListAlbums(ctx context.Context, limit, offset int) ([]*Album, error)
+	GetAlbum(ctx context.Context, id int) (*Album, error)
+	AddAlbum(ctx context.Context, album *Album) error
+	UpdateAlbum(ctx context.Context, album *Album) error
+	DeleteAlbum(ctx context.Context, id int) error
+	TagSong(ctx context.Context, songID int, kind TagKind, names []string) error
+	UntagSong(ctx context.Context, songID int, kind TagKind, names []string) error
+	ListTags(ctx context.Context, kind TagKind, limit, offset int) ([]*TagUsage, error)
+	ListRevisions(ctx context.Context, songID, limit, offset int) ([]*Revision, error)
+	GetRevision(ctx context.Context, songID, number int) (*Revision, error)
+	RestoreRevision(ctx context.Context, songID, number int) error
+	ListTrash(ctx context.Context, limit, offset int) ([]*TrashedSong, error)
+	RestoreSong(ctx context.Context, id int) error
+	PurgeTrash(ctx context.Context, before time.Time) (int, error)
+	Ping(ctx context.Context) error
+	Close()
 }
 
 var (
@@ -22,9 +59,49 @@ var (
 )
 
 type Song struct {
-	Artist      string
-	Title       string
+	ID int
+	// Artist is the primary artist of the song.
+	Artist string
+	Title  string
+	// ReleaseDate falls back to the earliest dated album of the song
+	// when the song has none of its own.
 	ReleaseDate time.Time
 	Lyrics      string
 	Link        string
+	// Score is the trigram similarity of a fuzzy GetSongs match, 0 otherwise.
+	Score float64
+	// Genres and Tags are normalized names in alphabetical order.
+	Genres []string
+	Tags   []string
+	// Albums lists the albums of the song by album ID. Only GetSong and
+	// GetSongByID fill it in.
+	Albums []SongAlbum
+	// Credits lists every artist of the song by role, the primary artist
+	// first. AddSong takes only the other roles; see CheckCredits.
+	Credits []Credit
+}
+
+// SongUpdate describes changes to an existing song. Empty Artist and Title
+// and nil pointers are left unchanged; a pointer to the zero value clears
+// the field.
+type SongUpdate struct {
+	// Artist moves the song to another artist, creating the artist if needed.
+	Artist string
+	// Title renames the song.
+	Title       string
+	ReleaseDate *time.Time
+	Lyrics      *string
+	Link        *string
+	// Genres and Tags replace the labels of the song when not nil.
+	Genres *[]string
+	Tags   *[]string
+	// Credits replaces the credits of the song other than the primary
+	// artist when not nil.
+	Credits *[]Credit
+}
+
+// IsEmpty reports whether the update changes nothing.
+func (u *SongUpdate) IsEmpty() bool {
+	return u.Artist == "" && u.Title == "" && u.ReleaseDate == nil && u.Lyrics == nil && u.Link == nil &&
+		u.Genres == nil && u.Tags == nil && u.Credits == nil
 }
@@ -0,0 +1,2190 @@
+// Package storagetest provides a conformance suite for storage.Storage
+// implementations. Every backend is expected to pass it unchanged.
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"song-library/internal/storage"
+)
+
+// Factory returns an empty storage with the given options for a single test.
+type Factory func(t *testing.T, opts storage.Options) storage.Storage
+
+// Run executes the whole suite against storages created by newStorage.
+func Run(t *testing.T, newStorage Factory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, s storage.Storage)
+	}{
+		{"AddSong", testAddSong},
+		{"GetSongsFilters", testGetSongsFilters},
+		{"GetSongsReleaseDate", testGetSongsReleaseDate},
+		{"GetSongsNotNull", testGetSongsNotNull},
+		{"GetSongsPagination", testGetSongsPagination},
+		{"GetSongsSort", testGetSongsSort},
+		{"GetSongsSortCase", testGetSongsSortCase},
+		{"GetSongsCursor", testGetSongsCursor},
+		{"CountSongs", testCountSongs},
+		{"SearchLyrics", testSearchLyrics},
+		{"GetSongsFuzzy", testGetSongsFuzzy},
+		{"Suggest", testSuggest},
+		{"Artists", testArtists},
+		{"DeleteArtist", testDeleteArtist},
+		{"MergeArtists", testMergeArtists},
+		{"MergeArtistsConflict", testMergeArtistsConflict},
+		{"ExactArtistNames", testExactArtistNames},
+		{"Albums", testAlbums},
+		{"AlbumTracks", testAlbumTracks},
+		{"AlbumReleaseDate", testAlbumReleaseDate},
+		{"GetSongsAlbum", testGetSongsAlbum},
+		{"SongTags", testSongTags},
+		{"UpdateSongTags", testUpdateSongTags},
+		{"GetSongsTags", testGetSongsTags},
+		{"ListTags", testListTags},
+		{"SongCredits", testSongCredits},
+		{"UpdateSongCredits", testUpdateSongCredits},
+		{"GetSongsCredits", testGetSongsCredits},
+		{"CreditedArtists", testCreditedArtists},
+		{"Revisions", testRevisions},
+		{"RestoreRevision", testRestoreRevision},
+		{"ArtistRevisions", testArtistRevisions},
+		{"Trash", testTrash},
+		{"TrashTitleReuse", testTrashTitleReuse},
+		{"PurgeTrash", testPurgeTrash},
+		{"GetSong", testGetSong},
+		{"GetSongLyrics", testGetSongLyrics},
+		{"DeleteSong", testDeleteSong},
+		{"UpdateSong", testUpdateSong},
+		{"SongByID", testSongByID},
+		{"RenameSong", testRenameSong},
+		{"MoveSong", testMoveSong},
+		{"ClearSongFields", testClearSongFields},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newStorage(t, storage.Options{}))
+		})
+	}
+
+	t.Run("NormalizedArtistNames", func(t *testing.T) {
+		testNormalizedArtistNames(t, newStorage(t, storage.Options{NormalizeArtistNames: true}))
+	})
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+var fixtures = []storage.Song{
+	{
+		Artist:      "Muse",
+		Title:       "Supermassive Black Hole",
+		ReleaseDate: date(2006, time.July, 16),
+		Lyrics:      "Ooh baby, don't you know I suffer?\\n\\nYou set my soul alight",
+		Link:        "https://www.youtube.com/watch?v=Xsp3_a-PMTw",
+	},
+	{
+		Artist:      "Muse",
+		Title:       "Uprising",
+		ReleaseDate: date(2009, time.September, 7),
+		Lyrics:      "Paranoia is in bloom\\n\\nThey will not force us\\n\\nThey will not control us",
+	},
+	{
+		Artist:      "The Beatles",
+		Title:       "Hey Jude",
+		ReleaseDate: date(1968, time.August, 26),
+		Link:        "https://www.youtube.com/watch?v=A_MjCqQoLLA",
+	},
+	{
+		Artist:      "The Beatles",
+		Title:       "Let It Be",
+		ReleaseDate: date(1970, time.March, 6),
+		Lyrics:      "When I find myself in times of trouble\\n\\nLet it be, let it be",
+	},
+}
+
+func seed(t *testing.T, s storage.Storage) {
+	t.Helper()
+
+	for i := range fixtures {
+		song := fixtures[i]
+		if err := s.AddSong(context.Background(), &song); err != nil {
+			t.Fatalf("AddSong(%q, %q): %v", song.Artist, song.Title, err)
+		}
+	}
+}
+
+func titles(songs []*storage.Song) []string {
+	res := make([]string, len(songs))
+	for i, song := range songs {
+		res[i] = song.Title
+	}
+	sort.Strings(res)
+	return res
+}
+
+func assertTitles(t *testing.T, songs []*storage.Song, want ...string) {
+	t.Helper()
+
+	got := titles(songs)
+	sort.Strings(want)
+	if len(got) != len(want) {
+		t.Fatalf("got songs %q, want %q", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("got songs %q, want %q", got, want)
+		}
+	}
+}
+
+// assertOrder is assertTitles for sorted results: the order must match too.
+func assertOrder(t *testing.T, songs []*storage.Song, want ...string) {
+	t.Helper()
+
+	got := make([]string, len(songs))
+	for i, song := range songs {
+		got[i] = song.Title
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got songs %q, want %q in this order", got, want)
+	}
+}
+
+func getSongs(t *testing.T, s storage.Storage, filter storage.SongFilter) []*storage.Song {
+	t.Helper()
+
+	songs, err := s.GetSongs(context.Background(), &filter, storage.Page{Limit: 100})
+	if err != nil {
+		t.Fatalf("GetSongs(%v): %v", filter, err)
+	}
+	return songs
+}
+
+func testAddSong(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+
+	dup := fixtures[0]
+	err := s.AddSong(ctx, &dup)
+	if !errors.Is(err, storage.ErrSongExists) {
+		t.Fatalf("AddSong duplicate: got %v, want %v", err, storage.ErrSongExists)
+	}
+
+	other := storage.Song{Artist: "Queen", Title: fixtures[0].Title}
+	if err := s.AddSong(ctx, &other); err != nil {
+		t.Fatalf("AddSong same title for another artist: %v", err)
+	}
+
+	got, err := s.GetSong(ctx, "Queen", fixtures[0].Title)
+	if err != nil {
+		t.Fatalf("GetSong after AddSong: %v", err)
+	}
+	if got.Artist != "Queen" {
+		t.Fatalf("got artist %q, want %q", got.Artist, "Queen")
+	}
+
+	want := fixtures[0]
+	got, err = s.GetSong(ctx, want.Artist, want.Title)
+	if err != nil {
+		t.Fatalf("GetSong: %v", err)
+	}
+	if got.Artist != want.Artist || got.Title != want.Title || got.Lyrics != want.Lyrics ||
+		got.Link != want.Link || !got.ReleaseDate.Equal(want.ReleaseDate) {
+		t.Fatalf("got %+v, want %+v", *got, want)
+	}
+}
+
+func testGetSongsFilters(t *testing.T, s storage.Storage) {
+	seed(t, s)
+
+	tests := []struct {
+		name   string
+		filter storage.SongFilter
+		want   []string
+	}{
+		{"empty", storage.SongFilter{}, []string{"Supermassive Black Hole", "Uprising", "Hey Jude", "Let It Be"}},
+		{"artist substring", storage.SongFilter{Artist: "beat"}, []string{"Hey Jude", "Let It Be"}},
+		{"artist case insensitive", storage.SongFilter{Artist: "MUSE"}, []string{"Supermassive Black Hole", "Uprising"}},
+		{"title substring", storage.SongFilter{Title: "black"}, []string{"Supermassive Black Hole"}},
+		{"artist and title", storage.SongFilter{Artist: "the", Title: "e"}, []string{"Hey Jude", "Let It Be"}},
+		{"artist and title disjoint", storage.SongFilter{Artist: "muse", Title: "jude"}, nil},
+		{"lyrics substring", storage.SongFilter{Lyrics: "NOT FORCE"}, []string{"Uprising"}},
+		{"lyrics and artist", storage.SongFilter{Lyrics: "o", Artist: "beatles"}, []string{"Let It Be"}},
+		{"wildcards match literally", storage.SongFilter{Title: "%"}, nil},
+		{"no match", storage.SongFilter{Artist: "Queen"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertTitles(t, getSongs(t, s, tt.filter), tt.want...)
+		})
+	}
+}
+
+func testGetSongsReleaseDate(t *testing.T, s storage.Storage) {
+	seed(t, s)
+	if err := s.AddSong(context.Background(), &storage.Song{Artist: "Queen", Title: "Unreleased"}); err != nil {
+		t.Fatalf("AddSong without release date: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		filter storage.SongFilter
+		want   []string
+	}{
+		{"single", storage.SongFilter{ReleasedFrom: date(2006, 7, 16), ReleasedTo: date(2006, 7, 16)}, []string{"Supermassive Black Hole"}},
+		{"single miss", storage.SongFilter{ReleasedFrom: date(2006, 7, 17), ReleasedTo: date(2006, 7, 17)}, nil},
+		{"range", storage.SongFilter{ReleasedFrom: date(1960, 1, 1), ReleasedTo: date(1969, 12, 31)}, []string{"Hey Jude"}},
+		{"range inclusive", storage.SongFilter{ReleasedFrom: date(1968, 8, 26), ReleasedTo: date(1970, 3, 6)}, []string{"Hey Jude", "Let It Be"}},
+		{"from only", storage.SongFilter{ReleasedFrom: date(2007, 1, 1)}, []string{"Uprising"}},
+		{"to only skips undated", storage.SongFilter{ReleasedTo: date(1969, 12, 31)}, []string{"Hey Jude"}},
+		{"range with artist", storage.SongFilter{ReleasedFrom: date(1900, 1, 1), ReleasedTo: date(2100, 1, 1), Artist: "muse"}, []string{"Supermassive Black Hole", "Uprising"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertTitles(t, getSongs(t, s, tt.filter), tt.want...)
+		})
+	}
+}
+
+func testGetSongsNotNull(t *testing.T, s storage.Storage) {
+	seed(t, s)
+
+	assertTitles(t, getSongs(t, s, storage.SongFilter{HasLyrics: storage.PresencePresent}),
+		"Supermassive Black Hole", "Uprising", "Let It Be")
+	assertTitles(t, getSongs(t, s, storage.SongFilter{HasLink: storage.PresencePresent}),
+		"Supermassive Black Hole", "Hey Jude")
+	assertTitles(t, getSongs(t, s, storage.SongFilter{HasLyrics: storage.PresencePresent, HasLink: storage.PresencePresent}),
+		"Supermassive Black Hole")
+	assertTitles(t, getSongs(t, s, storage.SongFilter{HasLyrics: storage.PresenceAbsent}),
+		"Hey Jude")
+	assertTitles(t, getSongs(t, s, storage.SongFilter{HasLink: storage.PresenceAbsent}),
+		"Uprising", "Let It Be")
+	assertTitles(t, getSongs(t, s, storage.SongFilter{HasLink: storage.PresenceAbsent, HasLyrics: storage.PresencePresent, Artist: "muse"}),
+		"Uprising")
+}
+
+func testGetSongsPagination(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+
+	var all []*storage.Song
+	for offset := 0; offset < len(fixtures)+1; offset += 3 {
+		page, err := s.GetSongs(ctx, &storage.SongFilter{}, storage.Page{Limit: 3, Offset: offset})
+		if err != nil {
+			t.Fatalf("GetSongs(limit=3, offset=%d): %v", offset, err)
+		}
+		if len(page) > 3 {
+			t.Fatalf("GetSongs(limit=3) returned %d songs", len(page))
+		}
+		all = append(all, page...)
+	}
+	assertTitles(t, all, "Supermassive Black Hole", "Uprising", "Hey Jude", "Let It Be")
+
+	page, err := s.GetSongs(ctx, &storage.SongFilter{Artist: "muse"}, storage.Page{Limit: 10, Offset: 1})
+	if err != nil {
+		t.Fatalf("GetSongs: %v", err)
+	}
+	if len(page) != 1 {
+		t.Fatalf("GetSongs(artist=muse, offset=1) returned %d songs, want 1", len(page))
+	}
+
+	page, err = s.GetSongs(ctx, &storage.SongFilter{}, storage.Page{Limit: 10, Offset: 100})
+	if err != nil {
+		t.Fatalf("GetSongs: %v", err)
+	}
+	if len(page) != 0 {
+		t.Fatalf("GetSongs(offset=100) returned %d songs, want 0", len(page))
+	}
+}
+
+func testGetSongsSort(t *testing.T, s storage.Storage) {
+	seed(t, s)
+
+	tests := []struct {
+		name string
+		sort []storage.SortField
+		want []string
+	}{
+		{"default by id", nil, []string{"Supermassive Black Hole", "Uprising", "Hey Jude", "Let It Be"}},
+		{"title", []storage.SortField{{Key: storage.SortTitle}}, []string{"Hey Jude", "Let It Be", "Supermassive Black Hole", "Uprising"}},
+		{"release date desc", []storage.SortField{{Key: storage.SortReleaseDate, Desc: true}}, []string{"Uprising", "Supermassive Black Hole", "Let It Be", "Hey Jude"}},
+		{"artist desc then title desc", []storage.SortField{{Key: storage.SortArtist, Desc: true}, {Key: storage.SortTitle, Desc: true}}, []string{"Let It Be", "Hey Jude", "Uprising", "Supermassive Black Hole"}},
+		{"artist with id tiebreaker", []storage.SortField{{Key: storage.SortArtist}}, []string{"Supermassive Black Hole", "Uprising", "Hey Jude", "Let It Be"}},
+		{"id desc", []storage.SortField{{Key: storage.SortID, Desc: true}}, []string{"Let It Be", "Hey Jude", "Uprising", "Supermassive Black Hole"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			songs, err := s.GetSongs(context.Background(), &storage.SongFilter{}, storage.Page{Limit: 100, Sort: tt.sort})
+			if err != nil {
+				t.Fatalf("GetSongs(sort=%v): %v", tt.sort, err)
+			}
+			assertOrder(t, songs, tt.want...)
+		})
+	}
+
+	// Paging through a sorted list visits every song exactly once.
+	var all []*storage.Song
+	sort := []storage.SortField{{Key: storage.SortArtist, Desc: true}}
+	for offset := 0; offset < len(fixtures); offset += 3 {
+		page, err := s.GetSongs(context.Background(), &storage.SongFilter{}, storage.Page{Limit: 3, Offset: offset, Sort: sort})
+		if err != nil {
+			t.Fatalf("GetSongs: %v", err)
+		}
+		all = append(all, page...)
+	}
+	assertTitles(t, all, "Hey Jude", "Let It Be", "Supermassive Black Hole", "Uprising")
+}
+
+func testGetSongsSortCase(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	// Names compare byte-wise: upper case before lower case, punctuation
+	// by its code point.
+	for _, song := range []storage.Song{
+		{Artist: "abba", Title: "waterloo"},
+		{Artist: "ABBA Tribute", Title: "Waterloo"},
+		{Artist: "Zz Top", Title: "_La Grange"},
+		{Artist: "abba", Title: "Mamma Mia"},
+	} {
+		if err := s.AddSong(ctx, &song); err != nil {
+			t.Fatalf("AddSong(%s - %s): %v", song.Artist, song.Title, err)
+		}
+	}
+
+	sort := []storage.SortField{{Key: storage.SortArtist}, {Key: storage.SortTitle}}
+	want := []string{"Waterloo", "_La Grange", "Mamma Mia", "waterloo"}
+	all := getSongsSorted(t, s, storage.Page{Limit: 100, Sort: sort})
+	assertOrder(t, all, want...)
+
+	for i, song := range all[:len(all)-1] {
+		cursor := storage.CursorAt(song, sort, false)
+		assertOrder(t, getSongsSorted(t, s, storage.Page{Limit: 100, Sort: sort, Cursor: &cursor}), want[i+1:]...)
+	}
+
+	assertOrder(t, getSongsSorted(t, s, storage.Page{Limit: 100, Sort: []storage.SortField{{Key: storage.SortTitle, Desc: true}}}),
+		"waterloo", "_La Grange", "Waterloo", "Mamma Mia")
+}
+
+func getSongsSorted(t *testing.T, s storage.Storage, page storage.Page) []*storage.Song {
+	t.Helper()
+
+	songs, err := s.GetSongs(context.Background(), &storage.SongFilter{}, page)
+	if err != nil {
+		t.Fatalf("GetSongs(%+v): %v", page, err)
+	}
+	return songs
+}
+
+func testGetSongsCursor(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+
+	// The Beatles before Muse, each artist's songs oldest first.
+	sort := []storage.SortField{{Key: storage.SortArtist, Desc: true}, {Key: storage.SortReleaseDate}}
+	want := []string{"Hey Jude", "Let It Be", "Supermassive Black Hole", "Uprising"}
+
+	all, err := s.GetSongs(ctx, &storage.SongFilter{}, storage.Page{Limit: 100, Sort: sort})
+	if err != nil {
+		t.Fatalf("GetSongs: %v", err)
+	}
+	assertOrder(t, all, want...)
+
+	for i, song := range all {
+		cursor := storage.CursorAt(song, sort, false)
+		after, err := s.GetSongs(ctx, &storage.SongFilter{}, storage.Page{Limit: 100, Sort: sort, Cursor: &cursor})
+		if err != nil {
+			t.Fatalf("GetSongs(after %q): %v", song.Title, err)
+		}
+		assertOrder(t, after, want[i+1:]...)
+
+		cursor = storage.CursorAt(song, sort, true)
+		before, err := s.GetSongs(ctx, &storage.SongFilter{}, storage.Page{Limit: 2, Sort: sort, Cursor: &cursor})
+		if err != nil {
+			t.Fatalf("GetSongs(before %q): %v", song.Title, err)
+		}
+		assertOrder(t, before, want[max(0, i-2):i]...)
+	}
+
+	// Songs deleted between pages do not shift the next page.
+	cursor := storage.CursorAt(all[1], sort, false)
+	if err := s.DeleteSong(ctx, "The Beatles", "Hey Jude"); err != nil {
+		t.Fatalf("DeleteSong: %v", err)
+	}
+	after, err := s.GetSongs(ctx, &storage.SongFilter{}, storage.Page{Limit: 1, Sort: sort, Cursor: &cursor})
+	if err != nil {
+		t.Fatalf("GetSongs: %v", err)
+	}
+	assertOrder(t, after, "Supermassive Black Hole")
+
+	// A cursor survives an encode/decode round trip.
+	decoded, err := storage.DecodeCursor(storage.CursorAt(all[2], sort, false).Encode())
+	if err != nil {
+		t.Fatalf("DecodeCursor: %v", err)
+	}
+	after, err = s.GetSongs(ctx, &storage.SongFilter{}, storage.Page{Limit: 100, Sort: decoded.Sort, Cursor: decoded})
+	if err != nil {
+		t.Fatalf("GetSongs: %v", err)
+	}
+	assertOrder(t, after, "Uprising")
+}
+
+func testCountSongs(t *testing.T, s storage.Storage) {
+	seed(t, s)
+
+	tests := []struct {
+		name   string
+		filter *storage.SongFilter
+		want   int
+	}{
+		{"nil filter", nil, 4},
+		{"empty", &storage.SongFilter{}, 4},
+		{"artist", &storage.SongFilter{Artist: "muse"}, 2},
+		{"presence", &storage.SongFilter{HasLink: storage.PresencePresent}, 2},
+		{"no match", &storage.SongFilter{Artist: "Queen"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.CountSongs(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatalf("CountSongs: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("CountSongs = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func testSearchLyrics(t *testing.T, s storage.Storage) {
+	seed(t, s)
+
+	search := func(t *testing.T, text string) []*storage.SearchHit {
+		t.Helper()
+		hits, err := s.SearchLyrics(context.Background(), storage.LyricsQuery{Text: text, Config: storage.SearchSimple}, 10, 0)
+		if err != nil {
+			t.Fatalf("SearchLyrics(%q): %v", text, err)
+		}
+		return hits
+	}
+	hitTitles := func(hits []*storage.SearchHit) []string {
+		var titles []string
+		for _, hit := range hits {
+			titles = append(titles, hit.Song.Title)
+		}
+		slices.Sort(titles)
+		return titles
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"alight", []string{"Supermassive Black Hole"}},
+		{"ALIGHT soul", []string{"Supermassive Black Hole"}},
+		{`"not force"`, []string{"Uprising"}},
+		{`"force not"`, nil},
+		{"trouble or paranoia", []string{"Let It Be", "Uprising"}},
+		{"they -control", nil},
+		{"baby -paranoia", []string{"Supermassive Black Hole"}},
+		{"jude", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := hitTitles(search(t, tt.query)); !slices.Equal(got, tt.want) {
+				t.Errorf("SearchLyrics(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+
+	hits := search(t, "alight")
+	if len(hits) != 1 {
+		t.Fatalf("SearchLyrics(alight) returned %d hits", len(hits))
+	}
+	hit := hits[0]
+	if hit.Song.Artist != "Muse" || hit.Song.ID == 0 || hit.Song.Lyrics != "" {
+		t.Errorf("SearchLyrics(alight) song = %+v", hit.Song)
+	}
+	if hit.Rank <= 0 {
+		t.Errorf("SearchLyrics(alight) rank = %v, want > 0", hit.Rank)
+	}
+	if want := storage.HighlightStart + "alight" + storage.HighlightStop; !strings.Contains(hit.Headline, want) {
+		t.Errorf("SearchLyrics(alight) headline = %q, want it to contain %q", hit.Headline, want)
+	}
+	if strings.Contains(hit.Headline, `\n`) {
+		t.Errorf("SearchLyrics(alight) headline = %q contains escaped newlines", hit.Headline)
+	}
+
+	if err := s.AddSong(context.Background(), &storage.Song{Artist: "Mallory", Title: "Markup", Lyrics: `Rock & <b>roll</b> "forever"`}); err != nil {
+		t.Fatalf("AddSong: %v", err)
+	}
+	hits = search(t, "forever")
+	if len(hits) != 1 {
+		t.Fatalf("SearchLyrics(forever) returned %d hits", len(hits))
+	}
+	// Lyrics are escaped, so only the highlight markers are markup.
+	headline := hits[0].Headline
+	if want := `&lt;b&gt;roll&lt;/b&gt; &#34;` + storage.HighlightStart + "forever" + storage.HighlightStop + `&#34;`; !strings.Contains(headline, want) {
+		t.Errorf("SearchLyrics(forever) headline = %q, want it to contain %q", headline, want)
+	}
+	if strings.Contains(headline, "<b>") {
+		t.Errorf("SearchLyrics(forever) headline = %q contains unescaped markup", headline)
+	}
+
+	if hits, err := s.SearchLyrics(context.Background(), storage.LyricsQuery{Text: "trouble or paranoia", Config: storage.SearchSimple}, 1, 1); err != nil || len(hits) != 1 {
+		t.Errorf("SearchLyrics(limit=1, offset=1) = %d hits, %v; want 1 hit", len(hits), err)
+	}
+}
+
+func testGetSongsFuzzy(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+
+	tests := []struct {
+		name   string
+		filter storage.SongFilter
+		want   []string
+	}{
+		{"artist typo", storage.SongFilter{Fuzzy: true, Artist: "Beatls"}, []string{"Hey Jude", "Let It Be"}},
+		{"title typo", storage.SongFilter{Fuzzy: true, Title: "Supermasive Blak Hole"}, []string{"Supermassive Black Hole"}},
+		{"artist and title", storage.SongFilter{Fuzzy: true, Artist: "muse", Title: "uprisin"}, []string{"Uprising"}},
+		{"too different", storage.SongFilter{Fuzzy: true, Artist: "Queen"}, nil},
+		{"substring is not enough", storage.SongFilter{Fuzzy: true, Title: "massive"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			songs := getSongs(t, s, tt.filter)
+			assertTitles(t, songs, tt.want...)
+			for _, song := range songs {
+				if song.Score < storage.FuzzyThreshold || song.Score > 1 {
+					t.Errorf("%q score = %v, want in [%v, 1]", song.Title, song.Score, storage.FuzzyThreshold)
+				}
+			}
+
+			count, err := s.CountSongs(ctx, &tt.filter)
+			if err != nil {
+				t.Fatalf("CountSongs: %v", err)
+			}
+			if count != len(tt.want) {
+				t.Errorf("CountSongs = %d, want %d", count, len(tt.want))
+			}
+		})
+	}
+
+	// Closer matches come first, whatever the sort.
+	if err := s.AddSong(ctx, &storage.Song{Artist: "Muse", Title: "Hole"}); err != nil {
+		t.Fatalf("AddSong: %v", err)
+	}
+	songs, err := s.GetSongs(ctx, &storage.SongFilter{Fuzzy: true, Title: "Black Hole"},
+		storage.Page{Limit: 10, Sort: []storage.SortField{{Key: storage.SortID, Desc: true}}})
+	if err != nil {
+		t.Fatalf("GetSongs: %v", err)
+	}
+	assertTitles(t, songs, "Supermassive Black Hole", "Hole")
+	if songs[0].Score <= songs[1].Score {
+		t.Errorf("scores = %v, %v; want descending", songs[0].Score, songs[1].Score)
+	}
+
+	for _, song := range getSongs(t, s, storage.SongFilter{Artist: "muse"}) {
+		if song.Score != 0 {
+			t.Errorf("%q score = %v without fuzzy, want 0", song.Title, song.Score)
+		}
+	}
+}
+
+func testSuggest(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+	if err := s.AddSong(ctx, &storage.Song{Artist: "Museum", Title: "Be_Here"}); err != nil {
+		t.Fatalf("AddSong: %v", err)
+	}
+
+	names := func(t *testing.T, kind storage.SuggestKind, prefix string, limit int) []string {
+		t.Helper()
+		suggestions, err := s.Suggest(ctx, kind, prefix, limit)
+		if err != nil {
+			t.Fatalf("Suggest(%s, %q): %v", kind, prefix, err)
+		}
+		var names []string
+		for _, sg := range suggestions {
+			if sg.ID == 0 {
+				t.Errorf("Suggest(%s, %q): %q has no ID", kind, prefix, sg.Name)
+			}
+			names = append(names, sg.Name)
+		}
+		return names
+	}
+
+	tests := []struct {
+		kind   storage.SuggestKind
+		prefix string
+		limit  int
+		want   []string
+	}{
+		{storage.SuggestArtist, "mu", 10, []string{"Muse", "Museum"}},
+		{storage.SuggestArtist, "BEAT", 10, []string{"The Beatles"}},
+		{storage.SuggestArtist, "e", 10, []string{"Muse", "Museum", "The Beatles"}},
+		{storage.SuggestArtist, "mu", 1, []string{"Muse"}},
+		{storage.SuggestSong, "be", 10, []string{"Be_Here", "Let It Be"}},
+		{storage.SuggestSong, "e_", 10, []string{"Be_Here"}},
+		{storage.SuggestSong, "u", 10, []string{"Uprising", "Hey Jude", "Supermassive Black Hole"}},
+		{storage.SuggestSong, "zzz", 10, nil},
+	}
+	for _, tt := range tests {
+		if got := names(t, tt.kind, tt.prefix, tt.limit); !slices.Equal(got, tt.want) {
+			t.Errorf("Suggest(%s, %q, %d) = %q, want %q", tt.kind, tt.prefix, tt.limit, got, tt.want)
+		}
+	}
+
+	songs, err := s.Suggest(ctx, storage.SuggestSong, "hey", 10)
+	if err != nil || len(songs) != 1 || songs[0].Artist != "The Beatles" {
+		t.Errorf("Suggest(song, hey) = %+v, %v; want Hey Jude by The Beatles", songs, err)
+	}
+
+	if _, err := s.Suggest(ctx, "album", "x", 10); !errors.Is(err, storage.ErrInvalidSuggestKind) {
+		t.Errorf("Suggest(album) error = %v, want ErrInvalidSuggestKind", err)
+	}
+}
+
+func testArtists(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+
+	queen := &storage.Artist{Name: "Queen"}
+	if err := s.AddArtist(ctx, queen); err != nil {
+		t.Fatalf("AddArtist: %v", err)
+	}
+	if queen.ID == 0 {
+		t.Fatal("AddArtist did not set the ID")
+	}
+	if err := s.AddArtist(ctx, &storage.Artist{Name: "Muse"}); !errors.Is(err, storage.ErrArtistExists) {
+		t.Errorf("AddArtist(duplicate) error = %v, want ErrArtistExists", err)
+	}
+
+	artists, err := s.ListArtists(ctx, 10, 0)
+	if err != nil {
+		t.Fatalf("ListArtists: %v", err)
+	}
+	var got []string
+	for _, a := range artists {
+		got = append(got, fmt.Sprintf("%s:%d", a.Name, a.SongCount))
+	}
+	if want := []string{"Muse:2", "The Beatles:2", "Queen:0"}; !slices.Equal(got, want) {
+		t.Errorf("ListArtists = %q, want %q", got, want)
+	}
+
+	page, err := s.ListArtists(ctx, 1, 1)
+	if err != nil || len(page) != 1 || page[0].Name != "The Beatles" {
+		t.Errorf("ListArtists(limit=1, offset=1) = %v, %v; want The Beatles", page, err)
+	}
+
+	got1, err := s.GetArtist(ctx, queen.ID)
+	if err != nil {
+		t.Fatalf("GetArtist: %v", err)
+	}
+	if got1.Name != "Queen" || got1.SongCount != 0 {
+		t.Errorf("GetArtist = %+v", got1)
+	}
+	if _, err := s.GetArtist(ctx, queen.ID+100); !errors.Is(err, storage.ErrArtistNotFound) {
+		t.Errorf("GetArtist(missing) error = %v, want ErrArtistNotFound", err)
+	}
+
+	// AddSong attaches to an artist created on its own.
+	if err := s.AddSong(ctx, &storage.Song{Artist: "Queen", Title: "Bohemian Rhapsody"}); err != nil {
+		t.Fatalf("AddSong: %v", err)
+	}
+	if got1, _ = s.GetArtist(ctx, queen.ID); got1.SongCount != 1 {
+		t.Errorf("Queen song count = %d, want 1", got1.SongCount)
+	}
+	assertTitles(t, getSongs(t, s, storage.SongFilter{ArtistID: queen.ID}), "Bohemian Rhapsody")
+
+	if err := s.RenameArtist(ctx, queen.ID, "Queen + Adam Lambert"); err != nil {
+		t.Fatalf("RenameArtist: %v", err)
+	}
+	song, err := s.GetSong(ctx, "Queen + Adam Lambert", "Bohemian Rhapsody")
+	if err != nil {
+		t.Fatalf("GetSong after rename: %v", err)
+	}
+	if song.Artist != "Queen + Adam Lambert" {
+		t.Errorf("song artist = %q after rename", song.Artist)
+	}
+	if err := s.RenameArtist(ctx, queen.ID, "Muse"); !errors.Is(err, storage.ErrArtistExists) {
+		t.Errorf("RenameArtist(taken) error = %v, want ErrArtistExists", err)
+	}
+	if err := s.RenameArtist(ctx, queen.ID+100, "Nobody"); !errors.Is(err, storage.ErrArtistNotFound) {
+		t.Errorf("RenameArtist(missing) error = %v, want ErrArtistNotFound", err)
+	}
+}
+
+func testDeleteArtist(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+
+	artistID := func(name string) int {
+		t.Helper()
+		artists, err := s.ListArtists(ctx, 100, 0)
+		if err != nil {
+			t.Fatalf("ListArtists: %v", err)
+		}
+		for _, a := range artists {
+			if a.Name == name {
+				return a.ID
+			}
+		}
+		t.Fatalf("artist %q not found", name)
+		return 0
+	}
+
+	muse := artistID("Muse")
+	if err := s.DeleteArtist(ctx, muse, false); !errors.Is(err, storage.ErrArtistHasSongs) {
+		t.Fatalf("DeleteArtist(with songs) error = %v, want ErrArtistHasSongs", err)
+	}
+	assertTitles(t, getSongs(t, s, storage.SongFilter{Artist: "muse"}), "Supermassive Black Hole", "Uprising")
+
+	if err := s.DeleteArtist(ctx, muse, true); err != nil {
+		t.Fatalf("DeleteArtist(cascade): %v", err)
+	}
+	assertTitles(t, getSongs(t, s, storage.SongFilter{}), "Hey Jude", "Let It Be")
+	if _, err := s.GetArtist(ctx, muse); !errors.Is(err, storage.ErrArtistNotFound) {
+		t.Errorf("GetArtist(deleted) error = %v, want ErrArtistNotFound", err)
+	}
+	if err := s.DeleteArtist(ctx, muse, true); !errors.Is(err, storage.ErrArtistNotFound) {
+		t.Errorf("DeleteArtist(deleted) error = %v, want ErrArtistNotFound", err)
+	}
+
+	empty := &storage.Artist{Name: "Queen"}
+	if err := s.AddArtist(ctx, empty); err != nil {
+		t.Fatalf("AddArtist: %v", err)
+	}
+	if err := s.DeleteArtist(ctx, empty.ID, false); err != nil {
+		t.Errorf("DeleteArtist(without songs): %v", err)
+	}
+}
+
+// artistIDs maps artist names to IDs.
+func artistIDs(t *testing.T, s storage.Storage) map[string]int {
+	t.Helper()
+
+	artists, err := s.ListArtists(context.Background(), 100, 0)
+	if err != nil {
+		t.Fatalf("ListArtists: %v", err)
+	}
+
+	ids := make(map[string]int, len(artists))
+	for _, a := range artists {
+		ids[a.Name] = a.ID
+	}
+	return ids
+}
+
+func testMergeArtists(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+	for _, song := range []storage.Song{
+		{Artist: "Beatles", Title: "Yesterday"},
+		{Artist: "the beatles", Title: "Help!"},
+		{Artist: "the beatles", Title: "Something"},
+	} {
+		if err := s.AddSong(ctx, &song); err != nil {
+			t.Fatalf("AddSong: %v", err)
+		}
+	}
+	ids := artistIDs(t, s)
+	album := addAlbum(t, s, "the beatles", "Help!", date(1965, time.August, 6), songIDs(t, s)["Help!"])
+
+	moved, err := s.MergeArtists(ctx, ids["The Beatles"], []int{ids["Beatles"], ids["the beatles"]})
+	if err != nil {
+		t.Fatalf("MergeArtists: %v", err)
+	}
+	if got, err := s.GetAlbum(ctx, album.ID); err != nil || got.Artist != "The Beatles" {
+		t.Errorf("GetAlbum after merge = %+v, %v, want it moved to The Beatles", got, err)
+	}
+	if moved != 3 {
+		t.Errorf("MergeArtists moved %d songs, want 3", moved)
+	}
+
+	assertTitles(t, getSongs(t, s, storage.SongFilter{ArtistID: ids["The Beatles"]}),
+		"Hey Jude", "Let It Be", "Yesterday", "Help!", "Something")
+	if got := artistIDs(t, s); len(got) != 2 {
+		t.Errorf("artists after merge = %v, want Muse and The Beatles", got)
+	}
+	if _, err := s.GetArtist(ctx, ids["Beatles"]); !errors.Is(err, storage.ErrArtistNotFound) {
+		t.Errorf("GetArtist(source) error = %v, want ErrArtistNotFound", err)
+	}
+
+	if _, err := s.MergeArtists(ctx, ids["The Beatles"], []int{ids["Beatles"]}); !errors.Is(err, storage.ErrArtistNotFound) {
+		t.Errorf("MergeArtists(deleted source) error = %v, want ErrArtistNotFound", err)
+	}
+}
+
+func testMergeArtistsConflict(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+	for _, song := range []storage.Song{
+		{Artist: "Beatles", Title: "Hey Jude"},
+		{Artist: "Beatles", Title: "Yesterday"},
+		{Artist: "the beatles", Title: "Yesterday"},
+		{Artist: "the beatles", Title: "Help!"},
+	} {
+		if err := s.AddSong(ctx, &song); err != nil {
+			t.Fatalf("AddSong: %v", err)
+		}
+	}
+	ids := artistIDs(t, s)
+	songIDs := make(map[string][]int)
+	for _, song := range getSongs(t, s, storage.SongFilter{}) {
+		songIDs[song.Title] = append(songIDs[song.Title], song.ID)
+	}
+
+	_, err := s.MergeArtists(ctx, ids["The Beatles"], []int{ids["Beatles"], ids["the beatles"]})
+	var conflictErr *storage.MergeConflictError
+	if !errors.As(err, &conflictErr) || !errors.Is(err, storage.ErrMergeConflict) {
+		t.Fatalf("MergeArtists error = %v, want a MergeConflictError", err)
+	}
+
+	want := []storage.MergeConflict{
+		{Title: "Hey Jude", SongIDs: songIDs["Hey Jude"]},
+		{Title: "Yesterday", SongIDs: songIDs["Yesterday"]},
+	}
+	if len(conflictErr.Conflicts) != len(want) {
+		t.Fatalf("conflicts = %+v, want %+v", conflictErr.Conflicts, want)
+	}
+	for i, c := range conflictErr.Conflicts {
+		if c.Title != want[i].Title || !slices.Equal(c.SongIDs, want[i].SongIDs) {
+			t.Errorf("conflict %d = %+v, want %+v", i, c, want[i])
+		}
+	}
+
+	// Nothing was moved.
+	if got := artistIDs(t, s); len(got) != 4 {
+		t.Errorf("artists after failed merge = %v, want all 4", got)
+	}
+	assertTitles(t, getSongs(t, s, storage.SongFilter{ArtistID: ids["the beatles"]}), "Yesterday", "Help!")
+}
+
+func testExactArtistNames(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+
+	if err := s.AddSong(ctx, &storage.Song{Artist: "the  beatles ", Title: "Hey Jude"}); err != nil {
+		t.Fatalf("AddSong(other spelling): %v", err)
+	}
+	if got := artistIDs(t, s); len(got) != 3 {
+		t.Errorf("artists = %v, want a separate \"the  beatles \"", got)
+	}
+}
+
+func testNormalizedArtistNames(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+
+	if err := s.AddSong(ctx, &storage.Song{Artist: "  the   BEATLES ", Title: "Yesterday"}); err != nil {
+		t.Fatalf("AddSong: %v", err)
+	}
+	if err := s.AddSong(ctx, &storage.Song{Artist: "the beatles", Title: "Hey Jude"}); !errors.Is(err, storage.ErrSongExists) {
+		t.Errorf("AddSong(duplicate in other spelling) error = %v, want ErrSongExists", err)
+	}
+	if err := s.AddArtist(ctx, &storage.Artist{Name: "MUSE"}); !errors.Is(err, storage.ErrArtistExists) {
+		t.Errorf("AddArtist(MUSE) error = %v, want ErrArtistExists", err)
+	}
+
+	queen := &storage.Artist{Name: "  Queen \t II "}
+	if err := s.AddArtist(ctx, queen); err != nil {
+		t.Fatalf("AddArtist: %v", err)
+	}
+	if queen.Name != "Queen II" {
+		t.Errorf("AddArtist stored %q, want %q", queen.Name, "Queen II")
+	}
+
+	ids := artistIDs(t, s)
+	if len(ids) != 3 {
+		t.Errorf("artists = %v, want Muse, The Beatles and Queen II", ids)
+	}
+	assertTitles(t, getSongs(t, s, storage.SongFilter{ArtistID: ids["The Beatles"]}), "Hey Jude", "Let It Be", "Yesterday")
+
+	if err := s.UpdateSong(ctx, "Muse", "Uprising", &storage.SongUpdate{Artist: "queen ii"}); err != nil {
+		t.Fatalf("UpdateSong(move): %v", err)
+	}
+	assertTitles(t, getSongs(t, s, storage.SongFilter{ArtistID: queen.ID}), "Uprising")
+
+	if err := s.RenameArtist(ctx, queen.ID, " the  BEATLES "); !errors.Is(err, storage.ErrArtistExists) {
+		t.Errorf("RenameArtist(the BEATLES) error = %v, want ErrArtistExists", err)
+	}
+	if err := s.RenameArtist(ctx, queen.ID, "  queen   ii "); err != nil {
+		t.Fatalf("RenameArtist(own name respelled): %v", err)
+	}
+	if artist, err := s.GetArtist(ctx, queen.ID); err != nil || artist.Name != "queen ii" {
+		t.Errorf("GetArtist after rename = %+v, %v, want name %q", artist, err, "queen ii")
+	}
+}
+
+// songIDs maps song titles to IDs.
+func songIDs(t *testing.T, s storage.Storage) map[string]int {
+	t.Helper()
+
+	ids := make(map[string]int)
+	for _, song := range getSongs(t, s, storage.SongFilter{}) {
+		ids[song.Title] = song.ID
+	}
+	return ids
+}
+
+// addAlbum adds an album of the given songs, numbered from 1.
+func addAlbum(t *testing.T, s storage.Storage, artist, title string, released time.Time, songs ...int) *storage.Album {
+	t.Helper()
+
+	album := &storage.Album{Artist: artist, Title: title, ReleaseDate: released}
+	for i, id := range songs {
+		album.Tracks = append(album.Tracks, storage.Track{Number: i + 1, SongID: id})
+	}
+	if err := s.AddAlbum(context.Background(), album); err != nil {
+		t.Fatalf("AddAlbum(%q): %v", title, err)
+	}
+	return album
+}
+
+func testAlbums(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+	ids := songIDs(t, s)
+
+	album := addAlbum(t, s, "Muse", "Black Holes and Revelations", date(2006, time.July, 3), ids["Supermassive Black Hole"])
+	if album.ID == 0 {
+		t.Fatal("AddAlbum did not set the ID")
+	}
+	hits := addAlbum(t, s, "Various Artists", "Hits", time.Time{}, ids["Hey Jude"], ids["Uprising"])
+
+	got, err := s.GetAlbum(ctx, hits.ID)
+	if err != nil {
+		t.Fatalf("GetAlbum: %v", err)
+	}
+	if got.Artist != "Various Artists" || got.Title != "Hits" || !got.ReleaseDate.IsZero() || got.TrackCount != 2 {
+		t.Errorf("GetAlbum = %+v", got)
+	}
+	wantTracks := []storage.Track{
+		{Number: 1, SongID: ids["Hey Jude"], Artist: "The Beatles", Title: "Hey Jude"},
+		{Number: 2, SongID: ids["Uprising"], Artist: "Muse", Title: "Uprising"},
+	}
+	if !slices.Equal(got.Tracks, wantTracks) {
+		t.Errorf("GetAlbum tracks = %+v, want %+v", got.Tracks, wantTracks)
+	}
+
+	list, err := s.ListAlbums(ctx, 10, 0)
+	if err != nil {
+		t.Fatalf("ListAlbums: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != album.ID || list[1].ID != hits.ID || list[1].TrackCount != 2 || list[1].Tracks != nil {
+		t.Errorf("ListAlbums = %+v", list)
+	}
+	if list, err := s.ListAlbums(ctx, 10, 1); err != nil || len(list) != 1 || list[0].ID != hits.ID {
+		t.Errorf("ListAlbums(offset 1) = %v, %v", list, err)
+	}
+
+	update := &storage.Album{
+		ID:          hits.ID,
+		Artist:      "Compilers",
+		Title:       "More Hits",
+		ReleaseDate: date(2000, time.January, 1),
+		CoverLink:   "https://example.com/cover.jpg",
+		Tracks:      []storage.Track{{Number: 1, SongID: ids["Let It Be"]}},
+	}
+	if err := s.UpdateAlbum(ctx, update); err != nil {
+		t.Fatalf("UpdateAlbum: %v", err)
+	}
+	got, err = s.GetAlbum(ctx, hits.ID)
+	if err != nil {
+		t.Fatalf("GetAlbum: %v", err)
+	}
+	if got.Artist != "Compilers" || got.Title != "More Hits" || !got.ReleaseDate.Equal(update.ReleaseDate) ||
+		got.CoverLink != update.CoverLink || len(got.Tracks) != 1 || got.Tracks[0].Title != "Let It Be" {
+		t.Errorf("GetAlbum after update = %+v", got)
+	}
+
+	if err := s.UpdateAlbum(ctx, &storage.Album{ID: 1000, Artist: "Muse", Title: "X"}); !errors.Is(err, storage.ErrAlbumNotFound) {
+		t.Errorf("UpdateAlbum(missing) error = %v, want ErrAlbumNotFound", err)
+	}
+
+	if err := s.DeleteAlbum(ctx, hits.ID); err != nil {
+		t.Fatalf("DeleteAlbum: %v", err)
+	}
+	if _, err := s.GetAlbum(ctx, hits.ID); !errors.Is(err, storage.ErrAlbumNotFound) {
+		t.Errorf("GetAlbum(deleted) error = %v, want ErrAlbumNotFound", err)
+	}
+	if err := s.DeleteAlbum(ctx, hits.ID); !errors.Is(err, storage.ErrAlbumNotFound) {
+		t.Errorf("DeleteAlbum(deleted) error = %v, want ErrAlbumNotFound", err)
+	}
+	if got := getSongs(t, s, storage.SongFilter{}); len(got) != len(fixtures) {
+		t.Errorf("DeleteAlbum removed songs: %d left", len(got))
+	}
+
+	// Albums go with their artist.
+	if err := s.DeleteArtist(ctx, artistIDs(t, s)["Muse"], true); err != nil {
+		t.Fatalf("DeleteArtist: %v", err)
+	}
+	if _, err := s.GetAlbum(ctx, album.ID); !errors.Is(err, storage.ErrAlbumNotFound) {
+		t.Errorf("GetAlbum(of deleted artist) error = %v, want ErrAlbumNotFound", err)
+	}
+}
+
+func testAlbumTracks(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+	ids := songIDs(t, s)
+
+	for name, tracks := range map[string][]storage.Track{
+		"repeated number": {{Number: 1, SongID: ids["Hey Jude"]}, {Number: 1, SongID: ids["Let It Be"]}},
+		"repeated song":   {{Number: 1, SongID: ids["Hey Jude"]}, {Number: 2, SongID: ids["Hey Jude"]}},
+		"zero number":     {{Number: 0, SongID: ids["Hey Jude"]}},
+	} {
+		err := s.AddAlbum(ctx, &storage.Album{Artist: "The Beatles", Title: "Past Masters", Tracks: tracks})
+		if !errors.Is(err, storage.ErrInvalidTrack) {
+			t.Errorf("AddAlbum(%s) error = %v, want ErrInvalidTrack", name, err)
+		}
+	}
+	err := s.AddAlbum(ctx, &storage.Album{Artist: "The Beatles", Title: "Past Masters", Tracks: []storage.Track{{Number: 1, SongID: 1000}}})
+	if !errors.Is(err, storage.ErrTrackSongNotFound) {
+		t.Errorf("AddAlbum(missing song) error = %v, want ErrTrackSongNotFound", err)
+	}
+	if list, err := s.ListAlbums(ctx, 10, 0); err != nil || len(list) != 0 {
+		t.Errorf("ListAlbums after failed adds = %v, %v", list, err)
+	}
+
+	// Tracks come back ordered by number.
+	album := &storage.Album{Artist: "The Beatles", Title: "1", Tracks: []storage.Track{
+		{Number: 2, SongID: ids["Let It Be"]},
+		{Number: 1, SongID: ids["Hey Jude"]},
+	}}
+	if err := s.AddAlbum(ctx, album); err != nil {
+		t.Fatalf("AddAlbum: %v", err)
+	}
+	other := addAlbum(t, s, "The Beatles", "Let It Be", date(1970, time.May, 8), ids["Let It Be"])
+
+	err = s.UpdateAlbum(ctx, &storage.Album{ID: album.ID, Artist: "The Beatles", Title: "1", Tracks: []storage.Track{{Number: 1, SongID: 1000}}})
+	if !errors.Is(err, storage.ErrTrackSongNotFound) {
+		t.Errorf("UpdateAlbum(missing song) error = %v, want ErrTrackSongNotFound", err)
+	}
+
+	got, err := s.GetAlbum(ctx, album.ID)
+	if err != nil {
+		t.Fatalf("GetAlbum: %v", err)
+	}
+	if len(got.Tracks) != 2 || got.Tracks[0].Title != "Hey Jude" || got.Tracks[1].Title != "Let It Be" {
+		t.Errorf("GetAlbum tracks = %+v, want Hey Jude, Let It Be", got.Tracks)
+	}
+
+	song, err := s.GetSongByID(ctx, ids["Let It Be"])
+	if err != nil {
+		t.Fatalf("GetSongByID: %v", err)
+	}
+	wantAlbums := []storage.SongAlbum{
+		{ID: album.ID, Title: "1", Track: 2},
+		{ID: other.ID, Title: "Let It Be", ReleaseDate: date(1970, time.May, 8), Track: 1},
+	}
+	if !slices.Equal(song.Albums, wantAlbums) {
+		t.Errorf("GetSongByID albums = %+v, want %+v", song.Albums, wantAlbums)
+	}
+	if song, err := s.GetSong(ctx, "the beatles", "let it be"); err != nil || len(song.Albums) != 2 {
+		t.Errorf("GetSong albums = %+v, %v", song, err)
+	}
+
+	// Deleting a song drops its tracks.
+	if err := s.DeleteSongByID(ctx, ids["Let It Be"]); err != nil {
+		t.Fatalf("DeleteSongByID: %v", err)
+	}
+	got, err = s.GetAlbum(ctx, album.ID)
+	if err != nil {
+		t.Fatalf("GetAlbum: %v", err)
+	}
+	if got.TrackCount != 1 || len(got.Tracks) != 1 || got.Tracks[0].Title != "Hey Jude" {
+		t.Errorf("GetAlbum after DeleteSongByID = %+v", got)
+	}
+}
+
+func testAlbumReleaseDate(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+	for _, title := range []string{"Help!", "Yesterday", "Something"} {
+		if err := s.AddSong(ctx, &storage.Song{Artist: "The Beatles", Title: title}); err != nil {
+			t.Fatalf("AddSong: %v", err)
+		}
+	}
+	ids := songIDs(t, s)
+
+	addAlbum(t, s, "The Beatles", "Help!", date(1965, time.August, 6), ids["Help!"], ids["Yesterday"])
+	addAlbum(t, s, "The Beatles", "Yesterday and Today", date(1966, time.June, 20), ids["Yesterday"])
+	addAlbum(t, s, "The Beatles", "Undated", time.Time{}, ids["Something"], ids["Hey Jude"])
+
+	for title, want := range map[string]time.Time{
+		"Help!":     date(1965, time.August, 6),
+		"Yesterday": date(1965, time.August, 6),
+		"Something": {},
+		// A song's own date wins.
+		"Hey Jude": date(1968, time.August, 26),
+	} {
+		song, err := s.GetSongByID(ctx, ids[title])
+		if err != nil {
+			t.Fatalf("GetSongByID: %v", err)
+		}
+		if !song.ReleaseDate.Equal(want) {
+			t.Errorf("%s release date = %v, want %v", title, song.ReleaseDate, want)
+		}
+	}
+
+	assertTitles(t, getSongs(t, s, storage.SongFilter{ReleasedFrom: date(1965, time.January, 1), ReleasedTo: date(1965, time.December, 31)}),
+		"Help!", "Yesterday")
+	assertTitles(t, getSongs(t, s, storage.SongFilter{ReleasedTo: date(1966, time.January, 1)}), "Help!", "Yesterday")
+
+	songs, err := s.GetSongs(ctx, &storage.SongFilter{Artist: "beatles"}, storage.Page{
+		Limit: 10,
+		Sort:  []storage.SortField{{Key: storage.SortReleaseDate}},
+	})
+	if err != nil {
+		t.Fatalf("GetSongs: %v", err)
+	}
+	var order []string
+	for _, song := range songs {
+		order = append(order, song.Title)
+	}
+	want := []string{"Something", "Help!", "Yesterday", "Hey Jude", "Let It Be"}
+	if !slices.Equal(order, want) {
+		t.Errorf("GetSongs by release date = %q, want %q", order, want)
+	}
+
+	// Clearing the song's own date falls back to the album.
+	if err := s.UpdateSongByID(ctx, ids["Hey Jude"], &storage.SongUpdate{ReleaseDate: &time.Time{}}); err != nil {
+		t.Fatalf("UpdateSongByID: %v", err)
+	}
+	if song, err := s.GetSongByID(ctx, ids["Hey Jude"]); err != nil || !song.ReleaseDate.IsZero() {
+		t.Errorf("Hey Jude on an undated album = %+v, %v, want no release date", song, err)
+	}
+}
+
+func testGetSongsAlbum(t *testing.T, s storage.Storage) {
+	seed(t, s)
+	ids := songIDs(t, s)
+
+	addAlbum(t, s, "Muse", "The Resistance", date(2009, time.September, 14), ids["Uprising"])
+	addAlbum(t, s, "The Beatles", "Let It Be", date(1970, time.May, 8), ids["Let It Be"])
+	addAlbum(t, s, "Various Artists", "Resistance 100%", time.Time{}, ids["Hey Jude"])
+
+	assertTitles(t, getSongs(t, s, storage.SongFilter{Album: "resistance"}), "Uprising", "Hey Jude")
+	assertTitles(t, getSongs(t, s, storage.SongFilter{Album: "100%"}), "Hey Jude")
+	assertTitles(t, getSongs(t, s, storage.SongFilter{Album: "let it", Artist: "beatles"}), "Let It Be")
+	assertTitles(t, getSongs(t, s, storage.SongFilter{Album: "abbey road"}))
+
+	count, err := s.CountSongs(context.Background(), &storage.SongFilter{Album: "resistance"})
+	if err != nil || count != 2 {
+		t.Errorf("CountSongs(album) = %d, %v, want 2", count, err)
+	}
+}
+
+// tagSongs adds songs of "Tagged" with the given genres and tags.
+func tagSongs(t *testing.T, s storage.Storage, songs map[string][2][]string) {
+	t.Helper()
+
+	for title, labels := range songs {
+		song := &storage.Song{Artist: "Tagged", Title: title, Genres: labels[0], Tags: labels[1]}
+		if err := s.AddSong(context.Background(), song); err != nil {
+			t.Fatalf("AddSong(%q): %v", title, err)
+		}
+	}
+}
+
+func testSongTags(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	song := &storage.Song{
+		Artist: "Muse",
+		Title:  "Uprising",
+		Genres: []string{"Alternative Rock", "rock"},
+		Tags:   []string{"  Live   favourite ", "anthem", "ANTHEM"},
+	}
+	if err := s.AddSong(ctx, song); err != nil {
+		t.Fatalf("AddSong: %v", err)
+	}
+	wantGenres := []string{"alternative rock", "rock"}
+	wantTags := []string{"anthem", "live favourite"}
+	if !slices.Equal(song.Genres, wantGenres) || !slices.Equal(song.Tags, wantTags) {
+		t.Errorf("AddSong normalized labels to %q and %q, want %q and %q", song.Genres, song.Tags, wantGenres, wantTags)
+	}
+
+	got, err := s.GetSongByID(ctx, song.ID)
+	if err != nil {
+		t.Fatalf("GetSongByID: %v", err)
+	}
+	if !slices.Equal(got.Genres, wantGenres) || !slices.Equal(got.Tags, wantTags) {
+		t.Errorf("GetSongByID labels = %q and %q, want %q and %q", got.Genres, got.Tags, wantGenres, wantTags)
+	}
+	if got, err := s.GetSong(ctx, "muse", "uprising"); err != nil || !slices.Equal(got.Tags, wantTags) {
+		t.Errorf("GetSong tags = %+v, %v, want %q", got, err, wantTags)
+	}
+	if list := getSongs(t, s, storage.SongFilter{}); len(list) != 1 || !slices.Equal(list[0].Genres, wantGenres) {
+		t.Errorf("GetSongs = %+v, want genres %q", list, wantGenres)
+	}
+
+	if err := s.TagSong(ctx, song.ID, storage.KindTag, []string{"Protest", "anthem"}); err != nil {
+		t.Fatalf("TagSong: %v", err)
+	}
+	if err := s.UntagSong(ctx, song.ID, storage.KindTag, []string{"live favourite", "never attached"}); err != nil {
+		t.Fatalf("UntagSong: %v", err)
+	}
+	if err := s.UntagSong(ctx, song.ID, storage.KindGenre, []string{"Rock"}); err != nil {
+		t.Fatalf("UntagSong(genre): %v", err)
+	}
+	got, err = s.GetSongByID(ctx, song.ID)
+	if err != nil {
+		t.Fatalf("GetSongByID: %v", err)
+	}
+	if want := []string{"anthem", "protest"}; !slices.Equal(got.Tags, want) {
+		t.Errorf("tags after TagSong and UntagSong = %q, want %q", got.Tags, want)
+	}
+	if want := []string{"alternative rock"}; !slices.Equal(got.Genres, want) {
+		t.Errorf("genres after UntagSong = %q, want %q", got.Genres, want)
+	}
+
+	if err := s.TagSong(ctx, 1000, storage.KindTag, []string{"x"}); !errors.Is(err, storage.ErrSongNotFound) {
+		t.Errorf("TagSong(missing song) error = %v, want ErrSongNotFound", err)
+	}
+	if err := s.UntagSong(ctx, 1000, storage.KindTag, []string{"x"}); !errors.Is(err, storage.ErrSongNotFound) {
+		t.Errorf("UntagSong(missing song) error = %v, want ErrSongNotFound", err)
+	}
+	if err := s.TagSong(ctx, song.ID, storage.KindTag, []string{"ok", " "}); !errors.Is(err, storage.ErrInvalidTag) {
+		t.Errorf("TagSong(blank) error = %v, want ErrInvalidTag", err)
+	}
+	long := strings.Repeat("x", storage.MaxTagLength+1)
+	if err := s.AddSong(ctx, &storage.Song{Artist: "Muse", Title: "Resistance", Tags: []string{long}}); !errors.Is(err, storage.ErrInvalidTag) {
+		t.Errorf("AddSong(long tag) error = %v, want ErrInvalidTag", err)
+	}
+}
+
+func testUpdateSongTags(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	tagSongs(t, s, map[string][2][]string{"One": {{"rock"}, {"live", "demo"}}})
+	id := getSongs(t, s, storage.SongFilter{})[0].ID
+
+	// Only the labels change.
+	if err := s.UpdateSongByID(ctx, id, &storage.SongUpdate{Tags: &[]string{"Studio"}}); err != nil {
+		t.Fatalf("UpdateSongByID(tags): %v", err)
+	}
+	got, err := s.GetSongByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetSongByID: %v", err)
+	}
+	if !slices.Equal(got.Tags, []string{"studio"}) || !slices.Equal(got.Genres, []string{"rock"}) {
+		t.Errorf("labels after tag update = %q and %q, want [rock] and [studio]", got.Genres, got.Tags)
+	}
+
+	if err := s.UpdateSong(ctx, "Tagged", "One", &storage.SongUpdate{Title: "Two", Genres: &[]string{}}); err != nil {
+		t.Fatalf("UpdateSong(clear genres): %v", err)
+	}
+	got, err = s.GetSongByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetSongByID: %v", err)
+	}
+	if got.Title != "Two" || len(got.Genres) != 0 || !slices.Equal(got.Tags, []string{"studio"}) {
+		t.Errorf("song after clearing genres = %+v", got)
+	}
+
+	err = s.UpdateSongByID(ctx, id, &storage.SongUpdate{Title: "Three", Tags: &[]string{""}})
+	if !errors.Is(err, storage.ErrInvalidTag) {
+		t.Errorf("UpdateSongByID(blank tag) error = %v, want ErrInvalidTag", err)
+	}
+	if got, err := s.GetSongByID(ctx, id); err != nil || got.Title != "Two" {
+		t.Errorf("failed update changed the song: %+v, %v", got, err)
+	}
+}
+
+func testGetSongsTags(t *testing.T, s storage.Storage) {
+	tagSongs(t, s, map[string][2][]string{
+		"Both":      {{"rock", "pop"}, {"live"}},
+		"Rock":      {{"rock"}, {"studio", "live"}},
+		"Pop":       {{"pop"}, nil},
+		"Untagged":  {nil, nil},
+		"Jazz Live": {{"jazz"}, {"live", "demo"}},
+	})
+
+	assertTitles(t, getSongs(t, s, storage.SongFilter{Genres: storage.TagFilter{Names: []string{"pop", "rock"}}}), "Both", "Rock", "Pop")
+	assertTitles(t, getSongs(t, s, storage.SongFilter{Genres: storage.TagFilter{Names: []string{"pop", "rock"}, All: true}}), "Both")
+	assertTitles(t, getSongs(t, s, storage.SongFilter{Tags: storage.TagFilter{Names: []string{"live"}}}), "Both", "Rock", "Jazz Live")
+	assertTitles(t, getSongs(t, s, storage.SongFilter{Tags: storage.TagFilter{Names: []string{"demo", "live"}, All: true}}), "Jazz Live")
+	assertTitles(t, getSongs(t, s, storage.SongFilter{Tags: storage.TagFilter{Names: []string{"rock"}}}))
+	assertTitles(t, getSongs(t, s, storage.SongFilter{
+		Genres: storage.TagFilter{Names: []string{"rock"}},
+		Tags:   storage.TagFilter{Names: []string{"studio"}},
+	}), "Rock")
+
+	count, err := s.CountSongs(context.Background(), &storage.SongFilter{Tags: storage.TagFilter{Names: []string{"live"}}})
+	if err != nil || count != 3 {
+		t.Errorf("CountSongs(tag) = %d, %v, want 3", count, err)
+	}
+}
+
+func testListTags(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	tagSongs(t, s, map[string][2][]string{
+		"A": {{"rock"}, {"live", "demo"}},
+		"B": {{"rock"}, {"live"}},
+		"C": {{"pop"}, {"live", "acoustic"}},
+	})
+
+	// list returns the usages as "name:count".
+	list := func(kind storage.TagKind, limit, offset int) []string {
+		t.Helper()
+		usages, err := s.ListTags(ctx, kind, limit, offset)
+		if err != nil {
+			t.Fatalf("ListTags(%s): %v", kind, err)
+		}
+		res := make([]string, len(usages))
+		for i, u := range usages {
+			res[i] = fmt.Sprintf("%s:%d", u.Name, u.SongCount)
+		}
+		return res
+	}
+
+	if got, want := list(storage.KindTag, 10, 0), []string{"live:3", "acoustic:1", "demo:1"}; !slices.Equal(got, want) {
+		t.Errorf("ListTags(tag) = %v, want %v", got, want)
+	}
+	if got, want := list(storage.KindGenre, 10, 0), []string{"rock:2", "pop:1"}; !slices.Equal(got, want) {
+		t.Errorf("ListTags(genre) = %v, want %v", got, want)
+	}
+	if got, want := list(storage.KindTag, 1, 1), []string{"acoustic:1"}; !slices.Equal(got, want) {
+		t.Errorf("ListTags(tag, 1, 1) = %v, want %v", got, want)
+	}
+
+	// Unused labels drop out of the list.
+	if err := s.DeleteSong(ctx, "Tagged", "C"); err != nil {
+		t.Fatalf("DeleteSong: %v", err)
+	}
+	if got, want := list(storage.KindTag, 10, 0), []string{"live:2", "demo:1"}; !slices.Equal(got, want) {
+		t.Errorf("ListTags(tag) after DeleteSong = %v, want %v", got, want)
+	}
+}
+
+func testGetSong(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+
+	got, err := s.GetSong(ctx, "the beatles", "HEY JUDE")
+	if err != nil {
+		t.Fatalf("GetSong case insensitive: %v", err)
+	}
+	if got.Title != "Hey Jude" || got.Artist != "The Beatles" {
+		t.Fatalf("got %q - %q, want The Beatles - Hey Jude", got.Artist, got.Title)
+	}
+
+	_, err = s.GetSong(ctx, "The Beatles", "Hey")
+	if !errors.Is(err, storage.ErrSongNotFound) {
+		t.Fatalf("GetSong partial title: got %v, want %v", err, storage.ErrSongNotFound)
+	}
+}
+
+func testGetSongLyrics(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+
+	tests := []struct {
+		limit, offset int
+		want          string
+	}{
+		{10, 0, "Paranoia is in bloom\\n\\nThey will not force us\\n\\nThey will not control us"},
+		{1, 0, "Paranoia is in bloom"},
+		{2, 1, "They will not force us\\n\\nThey will not control us"},
+		{1, 2, "They will not control us"},
+		{5, 3, ""},
+	}
+
+	for _, tt := range tests {
+		got, err := s.GetSongLyrics(ctx, "muse", "uprising", tt.limit, tt.offset)
+		if err != nil {
+			t.Fatalf("GetSongLyrics(limit=%d, offset=%d): %v", tt.limit, tt.offset, err)
+		}
+		if got != tt.want {
+			t.Fatalf("GetSongLyrics(limit=%d, offset=%d) = %q, want %q", tt.limit, tt.offset, got, tt.want)
+		}
+	}
+
+	_, err := s.GetSongLyrics(ctx, "Muse", "Hysteria", 10, 0)
+	if !errors.Is(err, storage.ErrSongNotFound) {
+		t.Fatalf("GetSongLyrics missing song: got %v, want %v", err, storage.ErrSongNotFound)
+	}
+}
+
+func testDeleteSong(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+
+	err := s.DeleteSong(ctx, "muse", "uprising")
+	if !errors.Is(err, storage.ErrSongNotFound) {
+		t.Fatalf("DeleteSong requires exact match: got %v, want %v", err, storage.ErrSongNotFound)
+	}
+
+	if err := s.DeleteSong(ctx, "Muse", "Uprising"); err != nil {
+		t.Fatalf("DeleteSong: %v", err)
+	}
+
+	_, err = s.GetSong(ctx, "Muse", "Uprising")
+	if !errors.Is(err, storage.ErrSongNotFound) {
+		t.Fatalf("GetSong after delete: got %v, want %v", err, storage.ErrSongNotFound)
+	}
+
+	err = s.DeleteSong(ctx, "Muse", "Uprising")
+	if !errors.Is(err, storage.ErrSongNotFound) {
+		t.Fatalf("DeleteSong twice: got %v, want %v", err, storage.ErrSongNotFound)
+	}
+
+	assertTitles(t, getSongs(t, s, storage.SongFilter{Artist: "muse"}), "Supermassive Black Hole")
+}
+
+func testUpdateSong(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+
+	err := s.UpdateSong(ctx, "Muse", "Uprising", &storage.SongUpdate{})
+	if !errors.Is(err, storage.NothingChanged) {
+		t.Fatalf("UpdateSong without fields: got %v, want %v", err, storage.NothingChanged)
+	}
+
+	err = s.UpdateSong(ctx, "Muse", "Hysteria", &storage.SongUpdate{Link: ptr("https://example.com")})
+	if !errors.Is(err, storage.ErrSongNotFound) {
+		t.Fatalf("UpdateSong missing song: got %v, want %v", err, storage.ErrSongNotFound)
+	}
+
+	err = s.UpdateSong(ctx, "muse", "uprising", &storage.SongUpdate{Link: ptr("https://example.com")})
+	if !errors.Is(err, storage.ErrSongNotFound) {
+		t.Fatalf("UpdateSong requires exact match: got %v, want %v", err, storage.ErrSongNotFound)
+	}
+
+	err = s.UpdateSong(ctx, "Muse", "Uprising", &storage.SongUpdate{Link: ptr("https://example.com")})
+	if err != nil {
+		t.Fatalf("UpdateSong: %v", err)
+	}
+
+	got, err := s.GetSong(ctx, "Muse", "Uprising")
+	if err != nil {
+		t.Fatalf("GetSong after update: %v", err)
+	}
+	want := fixtures[1]
+	want.Link = "https://example.com"
+	if got.Link != want.Link || got.Lyrics != want.Lyrics || !got.ReleaseDate.Equal(want.ReleaseDate) {
+		t.Fatalf("got %+v, want %+v", *got, want)
+	}
+
+	newDate := date(2009, time.August, 3)
+	err = s.UpdateSong(ctx, "Muse", "Uprising", &storage.SongUpdate{ReleaseDate: ptr(newDate), Lyrics: ptr("Rise up")})
+	if err != nil {
+		t.Fatalf("UpdateSong: %v", err)
+	}
+
+	got, err = s.GetSong(ctx, "Muse", "Uprising")
+	if err != nil {
+		t.Fatalf("GetSong after update: %v", err)
+	}
+	if got.Lyrics != "Rise up" || got.Link != want.Link || !got.ReleaseDate.Equal(newDate) {
+		t.Fatalf("got %+v after second update", *got)
+	}
+}
+
+func testSongByID(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+
+	song := storage.Song{Artist: "Queen", Title: "Bohemian Rhapsody", Lyrics: "Is this the real life?\\n\\nMama"}
+	if err := s.AddSong(ctx, &song); err != nil {
+		t.Fatalf("AddSong: %v", err)
+	}
+	if song.ID == 0 {
+		t.Fatal("AddSong did not set song ID")
+	}
+
+	byName, err := s.GetSong(ctx, "Queen", "Bohemian Rhapsody")
+	if err != nil {
+		t.Fatalf("GetSong: %v", err)
+	}
+	if byName.ID != song.ID {
+		t.Fatalf("GetSong returned ID %d, want %d", byName.ID, song.ID)
+	}
+
+	got, err := s.GetSongByID(ctx, song.ID)
+	if err != nil {
+		t.Fatalf("GetSongByID: %v", err)
+	}
+	if got.Artist != song.Artist || got.Title != song.Title || got.ID != song.ID {
+		t.Fatalf("GetSongByID = %+v, want %+v", *got, song)
+	}
+
+	lyrics, err := s.GetSongLyricsByID(ctx, song.ID, 1, 1)
+	if err != nil {
+		t.Fatalf("GetSongLyricsByID: %v", err)
+	}
+	if lyrics != "Mama" {
+		t.Fatalf("GetSongLyricsByID = %q, want %q", lyrics, "Mama")
+	}
+
+	err = s.UpdateSongByID(ctx, song.ID, &storage.SongUpdate{})
+	if !errors.Is(err, storage.NothingChanged) {
+		t.Fatalf("UpdateSongByID without fields: got %v, want %v", err, storage.NothingChanged)
+	}
+
+	if err := s.UpdateSongByID(ctx, song.ID, &storage.SongUpdate{Link: ptr("https://example.com")}); err != nil {
+		t.Fatalf("UpdateSongByID: %v", err)
+	}
+	got, err = s.GetSongByID(ctx, song.ID)
+	if err != nil {
+		t.Fatalf("GetSongByID: %v", err)
+	}
+	if got.Link != "https://example.com" || got.Lyrics != song.Lyrics {
+		t.Fatalf("GetSongByID after update = %+v", *got)
+	}
+
+	if err := s.DeleteSongByID(ctx, song.ID); err != nil {
+		t.Fatalf("DeleteSongByID: %v", err)
+	}
+
+	missing := song.ID
+	if _, err := s.GetSongByID(ctx, missing); !errors.Is(err, storage.ErrSongNotFound) {
+		t.Fatalf("GetSongByID missing: got %v, want %v", err, storage.ErrSongNotFound)
+	}
+	if _, err := s.GetSongLyricsByID(ctx, missing, 10, 0); !errors.Is(err, storage.ErrSongNotFound) {
+		t.Fatalf("GetSongLyricsByID missing: got %v, want %v", err, storage.ErrSongNotFound)
+	}
+	if err := s.UpdateSongByID(ctx, missing, &storage.SongUpdate{Link: ptr("x")}); !errors.Is(err, storage.ErrSongNotFound) {
+		t.Fatalf("UpdateSongByID missing: got %v, want %v", err, storage.ErrSongNotFound)
+	}
+	if err := s.DeleteSongByID(ctx, missing); !errors.Is(err, storage.ErrSongNotFound) {
+		t.Fatalf("DeleteSongByID missing: got %v, want %v", err, storage.ErrSongNotFound)
+	}
+}
+
+func testRenameSong(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+
+	err := s.UpdateSong(ctx, "Muse", "Uprising", &storage.SongUpdate{Title: "Supermassive Black Hole"})
+	if !errors.Is(err, storage.ErrSongExists) {
+		t.Fatalf("UpdateSong title collision: got %v, want %v", err, storage.ErrSongExists)
+	}
+	if _, err := s.GetSong(ctx, "Muse", "Uprising"); err != nil {
+		t.Fatalf("song changed after failed rename: %v", err)
+	}
+
+	err = s.UpdateSong(ctx, "Muse", "Uprising", &storage.SongUpdate{Title: "Uprising (Live)", Link: ptr("https://example.com")})
+	if err != nil {
+		t.Fatalf("UpdateSong rename: %v", err)
+	}
+
+	got, err := s.GetSong(ctx, "Muse", "Uprising (Live)")
+	if err != nil {
+		t.Fatalf("GetSong after rename: %v", err)
+	}
+	if got.Link != "https://example.com" || got.Lyrics != fixtures[1].Lyrics {
+		t.Fatalf("got %+v after rename", *got)
+	}
+
+	if err := s.UpdateSongByID(ctx, got.ID, &storage.SongUpdate{Title: "Uprising"}); err != nil {
+		t.Fatalf("UpdateSongByID rename: %v", err)
+	}
+	renamed, err := s.GetSongByID(ctx, got.ID)
+	if err != nil {
+		t.Fatalf("GetSongByID after rename: %v", err)
+	}
+	if renamed.Title != "Uprising" {
+		t.Fatalf("got title %q, want %q", renamed.Title, "Uprising")
+	}
+}
+
+func testMoveSong(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+
+	song, err := s.GetSong(ctx, "The Beatles", "Let It Be")
+	if err != nil {
+		t.Fatalf("GetSong: %v", err)
+	}
+
+	err = s.UpdateSongByID(ctx, song.ID, &storage.SongUpdate{Artist: "Paul McCartney"})
+	if err != nil {
+		t.Fatalf("UpdateSongByID move to new artist: %v", err)
+	}
+
+	moved, err := s.GetSongByID(ctx, song.ID)
+	if err != nil {
+		t.Fatalf("GetSongByID after move: %v", err)
+	}
+	if moved.Artist != "Paul McCartney" || moved.Title != "Let It Be" || moved.Lyrics != song.Lyrics {
+		t.Fatalf("got %+v after move", *moved)
+	}
+	assertTitles(t, getSongs(t, s, storage.SongFilter{Artist: "beatles"}), "Hey Jude")
+
+	if err := s.AddSong(ctx, &storage.Song{Artist: "Muse", Title: "Hey Jude"}); err != nil {
+		t.Fatalf("AddSong: %v", err)
+	}
+	err = s.UpdateSong(ctx, "The Beatles", "Hey Jude", &storage.SongUpdate{Artist: "Muse"})
+	if !errors.Is(err, storage.ErrSongExists) {
+		t.Fatalf("UpdateSong artist collision: got %v, want %v", err, storage.ErrSongExists)
+	}
+
+	err = s.UpdateSong(ctx, "The Beatles", "Hey Jude", &storage.SongUpdate{Artist: "Muse", Title: "Hey Jude (Cover)"})
+	if err != nil {
+		t.Fatalf("UpdateSong move and rename: %v", err)
+	}
+	assertTitles(t, getSongs(t, s, storage.SongFilter{Artist: "muse"}),
+		"Supermassive Black Hole", "Uprising", "Hey Jude", "Hey Jude (Cover)")
+}
+
+func testClearSongFields(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+
+	song, err := s.GetSong(ctx, "Muse", "Supermassive Black Hole")
+	if err != nil {
+		t.Fatalf("GetSong: %v", err)
+	}
+
+	err = s.UpdateSongByID(ctx, song.ID, &storage.SongUpdate{Link: ptr(""), ReleaseDate: ptr(time.Time{})})
+	if err != nil {
+		t.Fatalf("UpdateSongByID clear: %v", err)
+	}
+
+	got, err := s.GetSongByID(ctx, song.ID)
+	if err != nil {
+		t.Fatalf("GetSongByID: %v", err)
+	}
+	if got.Link != "" || !got.ReleaseDate.IsZero() || got.Lyrics != song.Lyrics {
+		t.Fatalf("got %+v after clearing link and release date", *got)
+	}
+	assertTitles(t, getSongs(t, s, storage.SongFilter{HasLink: storage.PresencePresent}), "Hey Jude")
+
+	err = s.UpdateSong(ctx, "Muse", "Supermassive Black Hole", &storage.SongUpdate{Lyrics: ptr("")})
+	if err != nil {
+		t.Fatalf("UpdateSong clear lyrics: %v", err)
+	}
+	assertTitles(t, getSongs(t, s, storage.SongFilter{HasLyrics: storage.PresencePresent}), "Uprising", "Let It Be")
+}
+
+// credits formats credits as "artist:role" for comparison.
+func credits(cs []storage.Credit) []string {
+	res := make([]string, len(cs))
+	for i, c := range cs {
+		res[i] = c.Artist + ":" + string(c.Role)
+	}
+	return res
+}
+
+func assertCredits(t *testing.T, s storage.Storage, id int, want ...string) {
+	t.Helper()
+
+	got, err := s.GetSongByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetSongByID(%d): %v", id, err)
+	}
+	if !slices.Equal(credits(got.Credits), want) {
+		t.Errorf("credits of song %d = %q, want %q", id, credits(got.Credits), want)
+	}
+}
+
+func testSongCredits(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	song := &storage.Song{
+		Artist: "Queen",
+		Title:  "Under Pressure",
+		Credits: []storage.Credit{
+			{Artist: "David Bowie", Role: storage.RoleFeatured},
+			{Artist: "Queen", Role: storage.RoleProducer},
+			{Artist: "David Bowie", Role: storage.RoleProducer},
+			{Artist: "David Bowie", Role: storage.RoleFeatured},
+		},
+	}
+	if err := s.AddSong(ctx, song); err != nil {
+		t.Fatalf("AddSong: %v", err)
+	}
+	want := []string{"Queen:primary", "David Bowie:featured", "David Bowie:producer", "Queen:producer"}
+	assertCredits(t, s, song.ID, want...)
+
+	got, err := s.GetSong(ctx, "queen", "under pressure")
+	if err != nil || !slices.Equal(credits(got.Credits), want) {
+		t.Errorf("GetSong credits = %+v, %v, want %q", got, err, want)
+	}
+	if list := getSongs(t, s, storage.SongFilter{}); len(list) != 1 || !slices.Equal(credits(list[0].Credits), want) {
+		t.Errorf("GetSongs = %+v, want credits %q", list, want)
+	}
+
+	// Credited artists are created like primary ones, without songs of their own.
+	artists, err := s.ListArtists(ctx, 10, 0)
+	if err != nil {
+		t.Fatalf("ListArtists: %v", err)
+	}
+	if len(artists) != 2 || artists[1].Name != "David Bowie" || artists[1].SongCount != 0 {
+		t.Errorf("ListArtists = %+v, want Queen and David Bowie without songs", artists)
+	}
+
+	for _, c := range []storage.Credit{
+		{Artist: "Queen", Role: storage.RolePrimary},
+		{Artist: "Queen", Role: "singer"},
+		{Artist: " ", Role: storage.RoleComposer},
+	} {
+		err := s.AddSong(ctx, &storage.Song{Artist: "Queen", Title: "Bad", Credits: []storage.Credit{c}})
+		if !errors.Is(err, storage.ErrInvalidCredit) {
+			t.Errorf("AddSong(credit %+v) error = %v, want ErrInvalidCredit", c, err)
+		}
+	}
+}
+
+func testUpdateSongCredits(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	song := &storage.Song{
+		Artist:  "Queen",
+		Title:   "Under Pressure",
+		Credits: []storage.Credit{{Artist: "David Bowie", Role: storage.RoleFeatured}},
+	}
+	if err := s.AddSong(ctx, song); err != nil {
+		t.Fatalf("AddSong: %v", err)
+	}
+
+	// Only the credits change.
+	upd := &storage.SongUpdate{Credits: &[]storage.Credit{{Artist: "Freddie Mercury", Role: storage.RoleComposer}}}
+	if err := s.UpdateSongByID(ctx, song.ID, upd); err != nil {
+		t.Fatalf("UpdateSongByID(credits): %v", err)
+	}
+	assertCredits(t, s, song.ID, "Queen:primary", "Freddie Mercury:composer")
+
+	// Moving the song changes the primary artist and keeps the other credits.
+	if err := s.UpdateSong(ctx, "Queen", "Under Pressure", &storage.SongUpdate{Artist: "Queen & David Bowie"}); err != nil {
+		t.Fatalf("UpdateSong(artist): %v", err)
+	}
+	assertCredits(t, s, song.ID, "Queen & David Bowie:primary", "Freddie Mercury:composer")
+
+	if err := s.UpdateSongByID(ctx, song.ID, &storage.SongUpdate{Credits: &[]storage.Credit{}}); err != nil {
+		t.Fatalf("UpdateSongByID(clear credits): %v", err)
+	}
+	assertCredits(t, s, song.ID, "Queen & David Bowie:primary")
+
+	upd = &storage.SongUpdate{Title: "Other", Credits: &[]storage.Credit{{Artist: "Queen", Role: storage.RolePrimary}}}
+	if err := s.UpdateSongByID(ctx, song.ID, upd); !errors.Is(err, storage.ErrInvalidCredit) {
+		t.Errorf("UpdateSongByID(primary credit) error = %v, want ErrInvalidCredit", err)
+	}
+	if got, err := s.GetSongByID(ctx, song.ID); err != nil || got.Title != "Under Pressure" {
+		t.Errorf("failed update changed the song: %+v, %v", got, err)
+	}
+}
+
+func testGetSongsCredits(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+
+	song := &storage.Song{
+		Artist:  "Queen",
+		Title:   "Under Pressure",
+		Credits: []storage.Credit{{Artist: "David Bowie", Role: storage.RoleFeatured}},
+	}
+	if err := s.AddSong(ctx, song); err != nil {
+		t.Fatalf("AddSong: %v", err)
+	}
+	if err := s.AddSong(ctx, &storage.Song{Artist: "David Bowie", Title: "Heroes"}); err != nil {
+		t.Fatalf("AddSong: %v", err)
+	}
+
+	assertTitles(t, getSongs(t, s, storage.SongFilter{Artist: "bowie"}), "Under Pressure", "Heroes")
+	assertTitles(t, getSongs(t, s, storage.SongFilter{Artist: "queen"}), "Under Pressure")
+	assertTitles(t, getSongs(t, s, storage.SongFilter{Artist: "david bowei", Fuzzy: true}), "Under Pressure", "Heroes")
+
+	// ArtistID still selects the primary artist only.
+	heroes := getSongs(t, s, storage.SongFilter{Title: "heroes"})[0]
+	assertTitles(t, getSongs(t, s, storage.SongFilter{ArtistID: heroes.Credits[0].ArtistID}), "Heroes")
+
+	count, err := s.CountSongs(ctx, &storage.SongFilter{Artist: "bowie"})
+	if err != nil || count != 2 {
+		t.Errorf("CountSongs(credited artist) = %d, %v, want 2", count, err)
+	}
+}
+
+func testCreditedArtists(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	add := func(artist, title string, cs ...storage.Credit) int {
+		t.Helper()
+		song := &storage.Song{Artist: artist, Title: title, Credits: cs}
+		if err := s.AddSong(ctx, song); err != nil {
+			t.Fatalf("AddSong(%q): %v", title, err)
+		}
+		return song.ID
+	}
+	featured := func(artist string) storage.Credit { return storage.Credit{Artist: artist, Role: storage.RoleFeatured} }
+
+	both := add("Queen", "Under Pressure", featured("Bowie"), featured("David Bowie"))
+	one := add("Queen", "Another One", featured("Bowie"))
+	add("Bowie", "Heroes")
+	add("David Bowie", "Space Oddity")
+	guest := add("Annie Lennox", "Duet", featured("Queen"))
+
+	ids := artistIDs(t, s)
+	if _, err := s.MergeArtists(ctx, ids["David Bowie"], []int{ids["Bowie"]}); err != nil {
+		t.Fatalf("MergeArtists: %v", err)
+	}
+	assertCredits(t, s, both, "Queen:primary", "David Bowie:featured")
+	assertCredits(t, s, one, "Queen:primary", "David Bowie:featured")
+
+	// Deleting an artist drops its credits on the songs of others.
+	if err := s.DeleteArtist(ctx, ids["Queen"], true); err != nil {
+		t.Fatalf("DeleteArtist: %v", err)
+	}
+	assertCredits(t, s, guest, "Annie Lennox:primary")
+	assertTitles(t, getSongs(t, s, storage.SongFilter{Artist: "bowie"}), "Heroes", "Space Oddity")
+}
+
+// revisions formats the revisions of a song, newest first, as "number:action:author".
+func revisions(t *testing.T, s storage.Storage, songID int) []string {
+	t.Helper()
+
+	list, err := s.ListRevisions(context.Background(), songID, 100, 0)
+	if err != nil {
+		t.Fatalf("ListRevisions(%d): %v", songID, err)
+	}
+
+	res := make([]string, len(list))
+	for i, rev := range list {
+		res[i] = fmt.Sprintf("%d:%s:%s", rev.Number, rev.Action, rev.Author)
+	}
+	return res
+}
+
+func testRevisions(t *testing.T, s storage.Storage) {
+	ctx := storage.WithAuthor(context.Background(), "alice")
+
+	song := &storage.Song{
+		Artist:      "Queen",
+		Title:       "Under Pressure",
+		ReleaseDate: date(1981, time.October, 26),
+		Lyrics:      "Pressure pushing down on me",
+		Credits:     []storage.Credit{{Artist: "David Bowie", Role: storage.RoleFeatured}},
+	}
+	if err := s.AddSong(ctx, song); err != nil {
+		t.Fatalf("AddSong: %v", err)
+	}
+	if err := s.UpdateSongByID(context.Background(), song.ID, &storage.SongUpdate{Lyrics: ptr("vandalized")}); err != nil {
+		t.Fatalf("UpdateSongByID: %v", err)
+	}
+	if err := s.TagSong(storage.WithAuthor(ctx, "bob"), song.ID, storage.KindGenre, []string{"Rock"}); err != nil {
+		t.Fatalf("TagSong: %v", err)
+	}
+	if err := s.DeleteSong(ctx, "Queen", "Under Pressure"); err != nil {
+		t.Fatalf("DeleteSong: %v", err)
+	}
+
+	want := []string{"4:delete:alice", "3:update:bob", "2:update:anonymous", "1:add:alice"}
+	if got := revisions(t, s, song.ID); !slices.Equal(got, want) {
+		t.Errorf("revisions = %q, want %q", got, want)
+	}
+
+	page, err := s.ListRevisions(ctx, song.ID, 2, 1)
+	if err != nil || len(page) != 2 || page[0].Number != 3 || page[1].Number != 2 {
+		t.Errorf("ListRevisions(limit 2, offset 1) = %+v, %v, want revisions 3 and 2", page, err)
+	}
+
+	first, err := s.GetRevision(ctx, song.ID, 1)
+	if err != nil {
+		t.Fatalf("GetRevision(1): %v", err)
+	}
+	snapshot := first.Song
+	if first.SongID != song.ID || first.CreatedAt.IsZero() || snapshot.Artist != "Queen" || snapshot.Title != "Under Pressure" ||
+		!snapshot.ReleaseDate.Equal(song.ReleaseDate) || snapshot.Lyrics != "Pressure pushing down on me" ||
+		!slices.Equal(credits(snapshot.Credits), []string{"David Bowie:featured"}) {
+		t.Errorf("GetRevision(1) = %+v", first)
+	}
+
+	// The delete revision keeps the song as it was before the delete.
+	last, err := s.GetRevision(ctx, song.ID, 4)
+	if err != nil {
+		t.Fatalf("GetRevision(4): %v", err)
+	}
+	if last.Song.Lyrics != "vandalized" || !slices.Equal(last.Song.Genres, []string{"rock"}) {
+		t.Errorf("delete revision = %+v, want the last state of the song", last.Song)
+	}
+
+	if _, err := s.GetRevision(ctx, song.ID, 5); !errors.Is(err, storage.ErrRevisionNotFound) {
+		t.Errorf("GetRevision(missing) error = %v, want ErrRevisionNotFound", err)
+	}
+	if _, err := s.ListRevisions(ctx, 1000, 10, 0); !errors.Is(err, storage.ErrSongNotFound) {
+		t.Errorf("ListRevisions(missing song) error = %v, want ErrSongNotFound", err)
+	}
+}
+
+func testRestoreRevision(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	song := &storage.Song{Artist: "Queen", Title: "Under Pressure", Lyrics: "Pressure", Genres: []string{"rock"}}
+	if err := s.AddSong(ctx, song); err != nil {
+		t.Fatalf("AddSong: %v", err)
+	}
+	upd := &storage.SongUpdate{
+		Title:   "Under Presure",
+		Lyrics:  ptr("vandalized"),
+		Genres:  &[]string{"pop"},
+		Credits: &[]storage.Credit{{Artist: "Nobody", Role: storage.RoleProducer}},
+	}
+	if err := s.UpdateSongByID(ctx, song.ID, upd); err != nil {
+		t.Fatalf("UpdateSongByID: %v", err)
+	}
+
+	if err := s.RestoreRevision(storage.WithAuthor(ctx, "admin"), song.ID, 1); err != nil {
+		t.Fatalf("RestoreRevision: %v", err)
+	}
+	got, err := s.GetSongByID(ctx, song.ID)
+	if err != nil {
+		t.Fatalf("GetSongByID: %v", err)
+	}
+	if got.Title != "Under Pressure" || got.Lyrics != "Pressure" || !slices.Equal(got.Genres, []string{"rock"}) ||
+		!slices.Equal(credits(got.Credits), []string{"Queen:primary"}) {
+		t.Errorf("restored song = %+v", got)
+	}
+	if got, want := revisions(t, s, song.ID), []string{"3:restore:admin", "2:update:anonymous", "1:add:anonymous"}; !slices.Equal(got, want) {
+		t.Errorf("revisions = %q, want %q", got, want)
+	}
+
+	// A deleted song comes back under its old ID.
+	if err := s.DeleteSongByID(ctx, song.ID); err != nil {
+		t.Fatalf("DeleteSongByID: %v", err)
+	}
+	if err := s.RestoreRevision(ctx, song.ID, 2); err != nil {
+		t.Fatalf("RestoreRevision(deleted): %v", err)
+	}
+	got, err = s.GetSongByID(ctx, song.ID)
+	if err != nil {
+		t.Fatalf("GetSongByID(restored): %v", err)
+	}
+	if got.Title != "Under Presure" || got.Lyrics != "vandalized" || !slices.Equal(credits(got.Credits), []string{"Queen:primary", "Nobody:producer"}) {
+		t.Errorf("restored deleted song = %+v", got)
+	}
+
+	// Restoring a title the artist now uses elsewhere changes nothing.
+	if err := s.AddSong(ctx, &storage.Song{Artist: "Queen", Title: "Under Pressure"}); err != nil {
+		t.Fatalf("AddSong: %v", err)
+	}
+	if err := s.RestoreRevision(ctx, song.ID, 1); !errors.Is(err, storage.ErrSongExists) {
+		t.Errorf("RestoreRevision(conflict) error = %v, want ErrSongExists", err)
+	}
+	if got, err := s.GetSongByID(ctx, song.ID); err != nil || got.Title != "Under Presure" {
+		t.Errorf("failed restore changed the song: %+v, %v", got, err)
+	}
+	if n := len(revisions(t, s, song.ID)); n != 5 {
+		t.Errorf("failed restore recorded a revision: %d revisions, want 5", n)
+	}
+
+	if err := s.RestoreRevision(ctx, song.ID, 10); !errors.Is(err, storage.ErrRevisionNotFound) {
+		t.Errorf("RestoreRevision(missing) error = %v, want ErrRevisionNotFound", err)
+	}
+}
+
+func testArtistRevisions(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+	if err := s.AddSong(ctx, &storage.Song{Artist: "Beatles", Title: "Yesterday"}); err != nil {
+		t.Fatalf("AddSong: %v", err)
+	}
+
+	ids := artistIDs(t, s)
+	if _, err := s.MergeArtists(ctx, ids["The Beatles"], []int{ids["Beatles"]}); err != nil {
+		t.Fatalf("MergeArtists: %v", err)
+	}
+	yesterday := getSongs(t, s, storage.SongFilter{Title: "yesterday"})[0]
+	if got, want := revisions(t, s, yesterday.ID), []string{"2:update:anonymous", "1:add:anonymous"}; !slices.Equal(got, want) {
+		t.Errorf("revisions of a merged song = %q, want %q", got, want)
+	}
+
+	muse := getSongs(t, s, storage.SongFilter{Artist: "muse"})
+	if err := s.DeleteArtist(ctx, ids["Muse"], true); err != nil {
+		t.Fatalf("DeleteArtist: %v", err)
+	}
+	for _, song := range muse {
+		if got, want := revisions(t, s, song.ID), []string{"2:delete:anonymous", "1:add:anonymous"}; !slices.Equal(got, want) {
+			t.Errorf("revisions of %q = %q, want %q", song.Title, got, want)
+		}
+	}
+}
+
+func trashIDs(t *testing.T, s storage.Storage) []int {
+	t.Helper()
+
+	songs, err := s.ListTrash(context.Background(), 100, 0)
+	if err != nil {
+		t.Fatalf("ListTrash: %v", err)
+	}
+
+	ids := make([]int, len(songs))
+	for i, song := range songs {
+		ids[i] = song.ID
+	}
+	return ids
+}
+
+func testTrash(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+
+	uprising, err := s.GetSong(ctx, "Muse", "Uprising")
+	if err != nil {
+		t.Fatalf("GetSong: %v", err)
+	}
+	album := &storage.Album{Artist: "Muse", Title: "The Resistance", Tracks: []storage.Track{{Number: 1, SongID: uprising.ID}}}
+	if err := s.AddAlbum(ctx, album); err != nil {
+		t.Fatalf("AddAlbum: %v", err)
+	}
+
+	if err := s.DeleteSongByID(storage.WithAuthor(ctx, "alice"), uprising.ID); err != nil {
+		t.Fatalf("DeleteSongByID: %v", err)
+	}
+
+	// A trashed song is hidden from every read.
+	assertTitles(t, getSongs(t, s, storage.SongFilter{Artist: "muse"}), "Supermassive Black Hole")
+	if n, err := s.CountSongs(ctx, &storage.SongFilter{Artist: "muse"}); err != nil || n != 1 {
+		t.Errorf("CountSongs = %d, %v, want 1", n, err)
+	}
+	if _, err := s.GetSongByID(ctx, uprising.ID); !errors.Is(err, storage.ErrSongNotFound) {
+		t.Errorf("GetSongByID(trashed) error = %v, want ErrSongNotFound", err)
+	}
+	if err := s.DeleteSongByID(ctx, uprising.ID); !errors.Is(err, storage.ErrSongNotFound) {
+		t.Errorf("DeleteSongByID(trashed) error = %v, want ErrSongNotFound", err)
+	}
+	if artist, err := s.GetArtist(ctx, artistIDs(t, s)["Muse"]); err != nil || artist.SongCount != 1 {
+		t.Errorf("GetArtist = %+v, %v, want 1 song", artist, err)
+	}
+	if got, err := s.GetAlbum(ctx, album.ID); err != nil || len(got.Tracks) != 0 || got.TrackCount != 0 {
+		t.Errorf("GetAlbum = %+v, %v, want no tracks", got, err)
+	}
+
+	trashed, err := s.ListTrash(ctx, 10, 0)
+	if err != nil {
+		t.Fatalf("ListTrash: %v", err)
+	}
+	if len(trashed) != 1 || trashed[0].ID != uprising.ID || trashed[0].Artist != "Muse" ||
+		trashed[0].Title != "Uprising" || trashed[0].DeletedAt.IsZero() {
+		t.Fatalf("ListTrash = %+v", trashed)
+	}
+
+	// Restoring brings the song back as it was, album track included.
+	if err := s.RestoreSong(storage.WithAuthor(ctx, "bob"), uprising.ID); err != nil {
+		t.Fatalf("RestoreSong: %v", err)
+	}
+	got, err := s.GetSongByID(ctx, uprising.ID)
+	if err != nil {
+		t.Fatalf("GetSongByID(restored): %v", err)
+	}
+	if got.Title != "Uprising" || got.Lyrics != uprising.Lyrics || !got.ReleaseDate.Equal(uprising.ReleaseDate) {
+		t.Errorf("restored song = %+v", got)
+	}
+	if got, err := s.GetAlbum(ctx, album.ID); err != nil || len(got.Tracks) != 1 || got.Tracks[0].SongID != uprising.ID {
+		t.Errorf("GetAlbum(restored) = %+v, %v", got, err)
+	}
+	if got, want := revisions(t, s, uprising.ID), []string{"3:restore:bob", "2:delete:alice", "1:add:anonymous"}; !slices.Equal(got, want) {
+		t.Errorf("revisions = %q, want %q", got, want)
+	}
+	if ids := trashIDs(t, s); len(ids) != 0 {
+		t.Errorf("trash after restore = %v, want empty", ids)
+	}
+
+	if err := s.RestoreSong(ctx, uprising.ID); !errors.Is(err, storage.ErrSongNotFound) {
+		t.Errorf("RestoreSong(live) error = %v, want ErrSongNotFound", err)
+	}
+	if err := s.RestoreSong(ctx, 1000); !errors.Is(err, storage.ErrSongNotFound) {
+		t.Errorf("RestoreSong(missing) error = %v, want ErrSongNotFound", err)
+	}
+
+	// Songs in the trash still keep their artist from being deleted.
+	if err := s.DeleteSong(ctx, "Muse", "Uprising"); err != nil {
+		t.Fatalf("DeleteSong: %v", err)
+	}
+	if err := s.DeleteSong(ctx, "Muse", "Supermassive Black Hole"); err != nil {
+		t.Fatalf("DeleteSong: %v", err)
+	}
+	if got := trashIDs(t, s); len(got) != 2 || got[1] != uprising.ID {
+		t.Errorf("trash = %v, want the most recently deleted first", got)
+	}
+	if err := s.DeleteArtist(ctx, artistIDs(t, s)["Muse"], false); !errors.Is(err, storage.ErrArtistHasSongs) {
+		t.Errorf("DeleteArtist(trashed songs) error = %v, want ErrArtistHasSongs", err)
+	}
+	if err := s.DeleteArtist(ctx, artistIDs(t, s)["Muse"], true); err != nil {
+		t.Fatalf("DeleteArtist(cascade): %v", err)
+	}
+	if ids := trashIDs(t, s); len(ids) != 0 {
+		t.Errorf("trash after deleting the artist = %v, want empty", ids)
+	}
+}
+
+func testTrashTitleReuse(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	old := &storage.Song{Artist: "Queen", Title: "Under Pressure", Lyrics: "old"}
+	if err := s.AddSong(ctx, old); err != nil {
+		t.Fatalf("AddSong: %v", err)
+	}
+	if err := s.DeleteSongByID(ctx, old.ID); err != nil {
+		t.Fatalf("DeleteSongByID: %v", err)
+	}
+
+	// The trashed song does not reserve its title.
+	if err := s.AddSong(ctx, &storage.Song{Artist: "Queen", Title: "Under Pressure", Lyrics: "new"}); err != nil {
+		t.Fatalf("AddSong(title of a trashed song): %v", err)
+	}
+	if err := s.RestoreSong(ctx, old.ID); !errors.Is(err, storage.ErrSongExists) {
+		t.Errorf("RestoreSong(title taken) error = %v, want ErrSongExists", err)
+	}
+	if ids := trashIDs(t, s); len(ids) != 1 || ids[0] != old.ID {
+		t.Errorf("trash after failed restore = %v, want [%d]", ids, old.ID)
+	}
+	if got, err := s.GetSong(ctx, "Queen", "Under Pressure"); err != nil || got.Lyrics != "new" {
+		t.Errorf("GetSong = %+v, %v, want the new song", got, err)
+	}
+}
+
+func testPurgeTrash(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	seed(t, s)
+
+	song, err := s.GetSong(ctx, "The Beatles", "Hey Jude")
+	if err != nil {
+		t.Fatalf("GetSong: %v", err)
+	}
+	if err := s.DeleteSongByID(ctx, song.ID); err != nil {
+		t.Fatalf("DeleteSongByID: %v", err)
+	}
+
+	if n, err := s.PurgeTrash(ctx, time.Now().Add(-time.Hour)); err != nil || n != 0 {
+		t.Errorf("PurgeTrash(before deletion) = %d, %v, want 0", n, err)
+	}
+	if n, err := s.PurgeTrash(ctx, time.Now().Add(time.Hour)); err != nil || n != 1 {
+		t.Errorf("PurgeTrash = %d, %v, want 1", n, err)
+	}
+	if ids := trashIDs(t, s); len(ids) != 0 {
+		t.Errorf("trash after purge = %v, want empty", ids)
+	}
+	if err := s.RestoreSong(ctx, song.ID); !errors.Is(err, storage.ErrSongNotFound) {
+		t.Errorf("RestoreSong(purged) error = %v, want ErrSongNotFound", err)
+	}
+	assertTitles(t, getSongs(t, s, storage.SongFilter{Artist: "the beatles"}), "Let It Be")
+
+	// Revisions outlive the purge, so the song can still be recreated.
+	if err := s.RestoreRevision(ctx, song.ID, 1); err != nil {
+		t.Fatalf("RestoreRevision(purged): %v", err)
+	}
+	if got, err := s.GetSongByID(ctx, song.ID); err != nil || got.Title != "Hey Jude" {
+		t.Errorf("GetSongByID(recreated) = %+v, %v", got, err)
+	}
+}
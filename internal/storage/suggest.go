@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidSuggestKind = errors.New("invalid suggestion kind")
+
+// SuggestKind selects what Suggest completes.
+type SuggestKind string
+
+const (
+	SuggestArtist SuggestKind = "artist"
+	SuggestSong   SuggestKind = "song"
+)
+
+// ParseSuggestKind validates a kind name. An empty name selects SuggestArtist.
+func ParseSuggestKind(name string) (SuggestKind, error) {
+	switch kind := SuggestKind(name); kind {
+	case "":
+		return SuggestArtist, nil
+	case SuggestArtist, SuggestSong:
+		return kind, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidSuggestKind, name)
+	}
+}
+
+// Suggestion is a completion returned by Suggest. ID is an artist ID or
+// a song ID depending on the kind; Artist is only set for songs.
+type Suggestion struct {
+	ID     int
+	Name   string
+	Artist string
+}
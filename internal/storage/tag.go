@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+var ErrInvalidTag = errors.New("invalid tag")
+
+// MaxTagLength is the longest genre or tag name the schema allows.
+const MaxTagLength = 100
+
+// TagKind tells genres from free-form tags. Both label songs the same
+// way but live in separate tables.
+type TagKind string
+
+const (
+	KindGenre TagKind = "genre"
+	KindTag   TagKind = "tag"
+)
+
+// TagFilter matches songs labelled with any of Names, or with all of them
+// if All is set. An empty filter matches every song. Names must be
+// normalized, as by NormalizeTags.
+type TagFilter struct {
+	Names []string
+	All   bool
+}
+
+// TagUsage is a genre or tag with the number of songs labelled with it.
+type TagUsage struct {
+	Name      string
+	SongCount int
+}
+
+// NormalizeTag returns the stored form of a genre or tag name: lower case,
+// trimmed, with inner whitespace collapsed.
+func NormalizeTag(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// NormalizeTags normalizes names and returns them sorted, without
+// duplicates. It returns ErrInvalidTag if a name is blank or too long.
+func NormalizeTags(names []string) ([]string, error) {
+	res := make([]string, 0, len(names))
+	for _, name := range names {
+		name = NormalizeTag(name)
+		if name == "" || utf8.RuneCountInString(name) > MaxTagLength {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidTag, name)
+		}
+		res = append(res, name)
+	}
+	slices.Sort(res)
+	return slices.Compact(res), nil
+}
@@ -0,0 +1,14 @@
+package storage
+
+import "time"
+
+// TrashedSong is a deleted song that can still be restored. Deleted songs
+// are hidden from every other read, keep their labels, credits and album
+// tracks, and no longer reserve their title under the artist.
+type TrashedSong struct {
+	ID     int
+	Artist string
+	Title  string
+	// DeletedAt is when the song was moved to the trash.
+	DeletedAt time.Time
+}
@@ -0,0 +1,21 @@
+package storage
+
+import "strings"
+
+// VerseSeparator separates verses in stored lyrics.
+const VerseSeparator = "\\n\\n"
+
+// PaginateVerses returns at most limit verses of lyrics starting from offset.
+func PaginateVerses(lyrics string, limit, offset int) string {
+	verses := strings.Split(lyrics, VerseSeparator)
+	if offset >= len(verses) {
+		return ""
+	}
+
+	end := offset + limit
+	if end > len(verses) {
+		end = len(verses)
+	}
+
+	return strings.Join(verses[offset:end], VerseSeparator)
+}
@@ -0,0 +1,6 @@
+-- Songs in the trash are purged; their revisions remain.
+DELETE FROM songs WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_songs_deleted_at;
+DROP INDEX IF EXISTS idx_songs_artist_title_live;
+ALTER TABLE songs ADD CONSTRAINT songs_artist_id_title_key UNIQUE (artist_id, title);
+ALTER TABLE songs DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,8 @@
+-- Deleted songs stay in the trash until they are restored or purged.
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+-- Only songs outside the trash reserve their title under the artist.
+ALTER TABLE songs DROP CONSTRAINT IF EXISTS songs_artist_id_title_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_songs_artist_title_live ON songs(artist_id, title) WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_songs_deleted_at ON songs(deleted_at) WHERE deleted_at IS NOT NULL;
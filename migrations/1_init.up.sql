@@ -14,5 +14,5 @@ CREATE TABLE IF NOT EXISTS songs (
     FOREIGN KEY (artist_id) REFERENCES artists(artist_id) ON DELETE CASCADE
 );
 
-CREATE INDEX idx_songs_title ON songs(title);
-CREATE INDEX idx_songs_release_date ON songs(release_date);
\ No newline at end of file
+CREATE INDEX IF NOT EXISTS idx_songs_title ON songs(title);
+CREATE INDEX IF NOT EXISTS idx_songs_release_date ON songs(release_date);
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_songs_lyrics_tsv;
+ALTER TABLE songs DROP COLUMN IF EXISTS lyrics_tsv;
@@ -0,0 +1,12 @@
+-- Lyrics keep verse breaks as a literal "\n", which the text search parser
+-- would glue to the next word, so they are turned into real newlines first.
+-- The vector holds unstemmed words plus English and Russian stems, so any
+-- of the three configurations can be used at query time.
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS lyrics_tsv tsvector
+    GENERATED ALWAYS AS (
+        to_tsvector('simple'::regconfig, replace(coalesce(lyrics, ''), '\n', E'\n')) ||
+        to_tsvector('english'::regconfig, replace(coalesce(lyrics, ''), '\n', E'\n')) ||
+        to_tsvector('russian'::regconfig, replace(coalesce(lyrics, ''), '\n', E'\n'))
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_songs_lyrics_tsv ON songs USING GIN (lyrics_tsv);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_songs_title_trgm;
+DROP INDEX IF EXISTS idx_artists_name_trgm;
+-- The pg_trgm extension is left installed: it may have existed before.
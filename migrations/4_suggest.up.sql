@@ -0,0 +1,3 @@
+-- Prefix lookups for autocomplete. Substring lookups use the trigram indexes.
+CREATE INDEX IF NOT EXISTS idx_artists_name_prefix ON artists (lower(artist_name) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_songs_title_prefix ON songs (lower(title) text_pattern_ops);
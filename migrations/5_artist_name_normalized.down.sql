@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_artists_name_normalized;
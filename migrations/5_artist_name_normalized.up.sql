@@ -0,0 +1,3 @@
+-- Looks up artists ignoring case and whitespace when NORMALIZE_ARTIST_NAMES is on.
+CREATE INDEX IF NOT EXISTS idx_artists_name_normalized
+    ON artists (lower(regexp_replace(btrim(artist_name), '\s+', ' ', 'g')));
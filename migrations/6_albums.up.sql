@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS albums (
+    album_id SERIAL PRIMARY KEY,
+    artist_id INT NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    release_date DATE,
+    cover_link VARCHAR(255),
+    FOREIGN KEY (artist_id) REFERENCES artists(artist_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_albums_artist_id ON albums(artist_id);
+
+-- A song may appear on several albums, once per album.
+CREATE TABLE IF NOT EXISTS album_tracks (
+    album_id INT NOT NULL,
+    song_id INT NOT NULL,
+    track_number INT NOT NULL CHECK (track_number > 0),
+    PRIMARY KEY (album_id, track_number),
+    UNIQUE (album_id, song_id),
+    FOREIGN KEY (album_id) REFERENCES albums(album_id) ON DELETE CASCADE,
+    FOREIGN KEY (song_id) REFERENCES songs(song_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_album_tracks_song_id ON album_tracks(song_id);
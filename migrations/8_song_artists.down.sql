@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS songs_sync_primary_artist ON songs;
+DROP FUNCTION IF EXISTS song_artists_sync_primary();
+DROP TABLE IF EXISTS song_artists;
@@ -0,0 +1,32 @@
+-- Credits of a song. The primary artist stays in songs.artist_id, which
+-- the trigger below mirrors here; the other roles are added by the API.
+CREATE TABLE IF NOT EXISTS song_artists (
+    song_id INT NOT NULL,
+    artist_id INT NOT NULL,
+    role VARCHAR(16) NOT NULL CHECK (role IN ('primary', 'featured', 'producer', 'composer')),
+    PRIMARY KEY (song_id, artist_id, role),
+    FOREIGN KEY (song_id) REFERENCES songs(song_id) ON DELETE CASCADE,
+    FOREIGN KEY (artist_id) REFERENCES artists(artist_id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_song_artists_primary ON song_artists(song_id) WHERE role = 'primary';
+CREATE INDEX IF NOT EXISTS idx_song_artists_artist_id ON song_artists(artist_id);
+
+CREATE OR REPLACE FUNCTION song_artists_sync_primary() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO song_artists(song_id, artist_id, role) VALUES (NEW.song_id, NEW.artist_id, 'primary');
+    ELSE
+        UPDATE song_artists SET artist_id = NEW.artist_id WHERE song_id = NEW.song_id AND role = 'primary';
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER songs_sync_primary_artist
+    AFTER INSERT OR UPDATE OF artist_id ON songs
+    FOR EACH ROW EXECUTE FUNCTION song_artists_sync_primary();
+
+INSERT INTO song_artists(song_id, artist_id, role)
+SELECT song_id, artist_id, 'primary' FROM songs
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,34 @@
+-- Every add, update, delete and restore of a song stores a full snapshot.
+-- Revisions outlive their songs, so song_id references nothing.
+CREATE TABLE IF NOT EXISTS song_revisions (
+    song_id INT NOT NULL,
+    revision INT NOT NULL CHECK (revision > 0),
+    action VARCHAR(16) NOT NULL CHECK (action IN ('add', 'update', 'delete', 'restore')),
+    author VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    artist_name VARCHAR(255) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    release_date DATE,
+    lyrics TEXT NOT NULL DEFAULT '',
+    link VARCHAR(255) NOT NULL DEFAULT '',
+    genres TEXT[] NOT NULL DEFAULT '{}',
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    -- Credits other than the primary artist: [{"artist": ..., "role": ...}].
+    credits JSONB NOT NULL DEFAULT '[]',
+    PRIMARY KEY (song_id, revision)
+);
+
+-- Existing songs start their history from their current state.
+INSERT INTO song_revisions(song_id, revision, action, author, artist_name, title, release_date, lyrics, link, genres, tags, credits)
+SELECT s.song_id, 1, 'add', 'migration', a.artist_name, s.title, s.release_date, COALESCE(s.lyrics, ''), COALESCE(s.link, ''),
+    ARRAY(SELECT g.name FROM song_genres x JOIN genres g ON g.genre_id = x.genre_id WHERE x.song_id = s.song_id ORDER BY g.name),
+    ARRAY(SELECT t.name FROM song_tags x JOIN tags t ON t.tag_id = x.tag_id WHERE x.song_id = s.song_id ORDER BY t.name),
+    COALESCE((
+        SELECT jsonb_agg(jsonb_build_object('artist', ca.artist_name, 'role', x.role) ORDER BY
+            array_position(ARRAY['primary', 'featured', 'producer', 'composer']::varchar[], x.role), ca.artist_name, ca.artist_id)
+        FROM song_artists x
+        JOIN artists ca ON ca.artist_id = x.artist_id
+        WHERE x.song_id = s.song_id AND x.role <> 'primary'), '[]')
+FROM songs s
+JOIN artists a ON a.artist_id = s.artist_id
+ON CONFLICT DO NOTHING;
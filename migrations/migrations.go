@@ -0,0 +1,9 @@
+// Package migrations embeds the SQL schema migrations.
+//
+// Files are named <version>_<name>.up.sql and <version>_<name>.down.sql.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS